@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	search "github.com/mbsoft31/quransearch/quransearch"
+	"log"
+)
+
+func main() {
+	quranPath := flag.String("quran", "data/quran.txt", "quran text to index")
+	indexPath := flag.String("out", "data/quran.idx", "index file to write")
+	flag.Parse()
+
+	qs, err := search.NewQuranSearch(*quranPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = qs.WriteIndexFile(*indexPath); err != nil {
+		log.Fatal(err)
+	}
+
+	// load it back so a broken index never ships
+	if _, err = search.NewQuranSearchFromIndex(*quranPath, *indexPath); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("wrote %s: %d ayat, format v%d\n", *indexPath, len(qs.Ayat.Ayat), search.INDEX_FORMAT_VERSION)
+}
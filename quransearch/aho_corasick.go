@@ -0,0 +1,130 @@
+package quransearch
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const patternSeparator = "|"
+
+// acNode state of the Aho-Corasick automaton
+type acNode struct {
+	next map[byte]int
+	fail int
+	out  []int // patterns ending at this state
+}
+
+// NewAhoCorasickMethod Constructor compiling the automaton of the patterns
+func NewAhoCorasickMethod(patterns []string) *AhoCorasickMethod {
+	ac := &AhoCorasickMethod{}
+	ac.compile(patterns)
+	return ac
+}
+
+// Search splits the pattern on "|" and finds all the parts in one pass
+func (ac *AhoCorasickMethod) Search(text, pattern string, max int) []SearchMatch {
+	return ac.SearchAll(text, strings.Split(pattern, patternSeparator), max)
+}
+
+// SearchAll finds every occurrence of every pattern in one pass over the
+// text, each match tagged with the pattern that produced it
+func (ac *AhoCorasickMethod) SearchAll(text string, patterns []string, max int) []SearchMatch {
+	start := time.Now()
+	matches := make([]SearchMatch, 0)
+
+	if !ac.compiled(patterns) {
+		ac.compile(patterns)
+	}
+	if len(ac.Patterns) == 0 || max == 0 {
+		return matches
+	}
+
+	state := 0
+	for i := 0; i < len(text); i++ {
+		state = ac.step(state, text[i])
+		for _, n := range ac.nodes[state].out {
+			p := ac.Patterns[n]
+			match := newMatch(ac.Table, text, i+1-len(p), time.Since(start))
+			match.Length = len(p)
+			match.Pattern = p
+			matches = append(matches, *match)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Index < matches[j].Index
+	})
+	if max > 0 && len(matches) > max {
+		matches = matches[:max]
+	}
+	return matches
+}
+
+func (ac *AhoCorasickMethod) step(state int, c byte) int {
+	for {
+		if next, ok := ac.nodes[state].next[c]; ok {
+			return next
+		}
+		if state == 0 {
+			return 0
+		}
+		state = ac.nodes[state].fail
+	}
+}
+
+func (ac *AhoCorasickMethod) compiled(patterns []string) bool {
+	if ac.nodes == nil || len(patterns) != len(ac.source) {
+		return false
+	}
+	for i := range patterns {
+		if patterns[i] != ac.source[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// compile Build the trie of the patterns then its failure links
+func (ac *AhoCorasickMethod) compile(patterns []string) {
+	ac.source = append([]string(nil), patterns...)
+	ac.Patterns = ac.Patterns[:0]
+	ac.nodes = []acNode{{next: map[byte]int{}}}
+
+	seen := make(map[string]bool)
+	for _, p := range patterns {
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+
+		state := 0
+		for i := 0; i < len(p); i++ {
+			next, ok := ac.nodes[state].next[p[i]]
+			if !ok {
+				next = len(ac.nodes)
+				ac.nodes = append(ac.nodes, acNode{next: map[byte]int{}})
+				ac.nodes[state].next[p[i]] = next
+			}
+			state = next
+		}
+		ac.nodes[state].out = append(ac.nodes[state].out, len(ac.Patterns))
+		ac.Patterns = append(ac.Patterns, p)
+	}
+
+	// breadth first, so the failure state of a node is always done before it
+	queue := make([]int, 0, len(ac.nodes))
+	for _, next := range ac.nodes[0].next {
+		queue = append(queue, next)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for c, next := range ac.nodes[state].next {
+			fail := ac.step(ac.nodes[state].fail, c)
+			ac.nodes[next].fail = fail
+			ac.nodes[next].out = append(ac.nodes[next].out, ac.nodes[fail].out...)
+			queue = append(queue, next)
+		}
+	}
+}
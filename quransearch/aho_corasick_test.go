@@ -0,0 +1,37 @@
+package quransearch
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestAhoCorasickMethodEquivalence(t *testing.T) {
+	testEquivalence(t, func(table *AyaTable) SearchMethod {
+		return &AhoCorasickMethod{Table: table}
+	})
+}
+
+func TestAhoCorasickSearchAll(t *testing.T) {
+	qs := newTestSearch(t)
+	patterns := []string{"الرحمن", "الرحيم", "رحم", "الله"}
+	got := (&AhoCorasickMethod{Table: qs.Ayat}).SearchAll(qs.Quran, patterns, -1)
+
+	var want []SearchMatch
+	for _, p := range patterns {
+		for _, m := range (&BruteForceMethod{Table: qs.Ayat}).Search(qs.Quran, p, -1) {
+			m.Pattern = p
+			want = append(want, m)
+		}
+	}
+	sort.SliceStable(want, func(i, j int) bool {
+		return want[i].Index < want[j].Index
+	})
+	sameMatches(t, strings.Join(patterns, "|"), got, want)
+
+	for _, m := range got {
+		if qs.Quran[m.Index:m.Index+m.Length] != m.Pattern {
+			t.Fatalf("match at %d of length %d is not %q", m.Index, m.Length, m.Pattern)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package quransearch
+
+import (
+	"strings"
+)
+
+const MAX_ARABIZI_CANDIDATES = 256
+
+// arabiziUnits the Arabic letters a Latin spelling can stand for, longest
+// spellings first; "" is a short vowel that is not written
+var arabiziUnits = []struct {
+	latin  string
+	arabic []string
+}{
+	{"sh", []string{"ش"}},
+	{"ch", []string{"ش"}},
+	{"th", []string{"ث", "ذ"}},
+	{"dh", []string{"ذ", "ض"}},
+	{"kh", []string{"خ"}},
+	{"gh", []string{"غ"}},
+	{"aa", []string{"ا"}},
+	{"ee", []string{"ي"}},
+	{"ii", []string{"ي"}},
+	{"oo", []string{"و"}},
+	{"ou", []string{"و"}},
+	{"uu", []string{"و"}},
+	{"a", []string{"", "ا"}},
+	{"e", []string{"", "ي"}},
+	{"i", []string{"", "ي"}},
+	{"o", []string{"", "و"}},
+	{"u", []string{"", "و"}},
+	{"b", []string{"ب"}},
+	{"p", []string{"ب"}},
+	{"t", []string{"ت", "ط"}},
+	{"j", []string{"ج"}},
+	{"g", []string{"ج", "غ"}},
+	{"h", []string{"ه", "ح"}},
+	{"d", []string{"د", "ض"}},
+	{"r", []string{"ر"}},
+	{"z", []string{"ز", "ظ", "ذ"}},
+	{"s", []string{"س", "ص"}},
+	{"c", []string{"ك", "س"}},
+	{"f", []string{"ف"}},
+	{"v", []string{"ف"}},
+	{"q", []string{"ق"}},
+	{"k", []string{"ك", "ق"}},
+	{"l", []string{"ل"}},
+	{"m", []string{"م"}},
+	{"n", []string{"ن"}},
+	{"w", []string{"و"}},
+	{"y", []string{"ي"}},
+	{"2", []string{"ء", "أ"}},
+	{"3", []string{"ع"}},
+	{"5", []string{"خ"}},
+	{"6", []string{"ط"}},
+	{"7", []string{"ح"}},
+	{"8", []string{"غ", "ق"}},
+	{"9", []string{"ص", "ق"}},
+	{"'", []string{"ء", "أ", "ع", ""}},
+	{"`", []string{"ع"}},
+}
+
+// initialVowels the hamza carriers of a word starting with a vowel
+var initialVowels = map[byte][]string{
+	'a': {"ا", "أ", "إ", "ع"},
+	'e': {"إ", "ا", "ع"},
+	'i': {"إ", "ا"},
+	'o': {"أ", "ا", "ع"},
+	'u': {"أ", "ا", "ع"},
+}
+
+// finalSpellings the endings written differently at the end of a word
+var finalSpellings = map[string][]string{
+	"ah": {"ة", "ه", "اه", "ح"},
+	"a":  {"ا", "ة", "ى", ""},
+	"i":  {"ي", "ى", ""},
+}
+
+// sunLetters the spellings of the letters the article assimilates to
+var sunLetters = []string{"sh", "th", "dh", "t", "d", "r", "z", "s", "n"}
+
+// ExpandArabizi Return the Arabic spellings a romanized query may stand for,
+// at most MAX_ARABIZI_CANDIDATES of them
+func ExpandArabizi(q string) []string {
+	return expandArabizi(q, nil)
+}
+
+// expandArabizi Expand the query word by word and letter by letter, keeping
+// only the partial spellings accepted by keep when it is given
+func expandArabizi(q string, keep func(string) bool) []string {
+	candidates := []string{""}
+	for n, word := range strings.Fields(strings.ToLower(q)) {
+		if n > 0 {
+			candidates = extendCandidates(candidates, []string{" "}, keep)
+		}
+		for _, options := range arabiziWord(word) {
+			candidates = extendCandidates(candidates, options, keep)
+			if len(candidates) == 0 {
+				return nil
+			}
+		}
+	}
+	if len(candidates) == 1 && candidates[0] == "" {
+		return nil
+	}
+	return candidates
+}
+
+func extendCandidates(candidates, options []string, keep func(string) bool) []string {
+	next := make([]string, 0, len(candidates)*len(options))
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		for _, o := range options {
+			s := c + o
+			if seen[s] || (keep != nil && s != "" && !keep(s)) {
+				continue
+			}
+			seen[s] = true
+			next = append(next, s)
+			if len(next) >= MAX_ARABIZI_CANDIDATES {
+				return next
+			}
+		}
+	}
+	return next
+}
+
+// arabiziWord Split a romanized word into the options of each of its letters
+func arabiziWord(word string) [][]string {
+	var letters [][]string
+
+	if rest, ok := arabiziArticle(word); ok {
+		letters = append(letters, []string{"ال"})
+		word = rest
+	} else if options, ok := initialVowels[word[0]]; ok && len(word) > 1 {
+		letters = append(letters, options)
+		word = word[1:]
+	}
+
+	for len(word) > 0 {
+		if options, ok := finalSpellings[word]; ok {
+			letters = append(letters, options)
+			break
+		}
+		if options := arabiziUnit(&word); options != nil {
+			letters = append(letters, options)
+		}
+	}
+	return letters
+}
+
+// arabiziArticle Return the word without its article: "al" or "el" before a
+// consonant or a hyphen, or the article assimilated as in "ar-rahim"
+func arabiziArticle(word string) (string, bool) {
+	if len(word) < 4 || (word[0] != 'a' && word[0] != 'e') {
+		return word, false
+	}
+	if word[1] == 'l' {
+		rest := strings.TrimPrefix(word[2:], "-")
+		if _, vowel := initialVowels[rest[0]]; rest != "" && !vowel {
+			return rest, true
+		}
+		return word, false
+	}
+	for _, sun := range sunLetters {
+		rest, ok := strings.CutPrefix(word[1:], sun)
+		if !ok {
+			continue
+		}
+		rest = strings.TrimPrefix(rest, "-")
+		if strings.HasPrefix(rest, sun) && len(rest) > len(sun) {
+			return rest, true
+		}
+	}
+	return word, false
+}
+
+// arabiziUnit Consume the longest spelling at the start of the word and
+// return its options; a doubled consonant is written once, under a shadda
+func arabiziUnit(word *string) []string {
+	for _, unit := range arabiziUnits {
+		rest, ok := strings.CutPrefix(*word, unit.latin)
+		if !ok {
+			continue
+		}
+		if _, vowel := initialVowels[unit.latin[0]]; !vowel {
+			rest = strings.TrimPrefix(rest, unit.latin)
+		}
+		*word = rest
+		return unit.arabic
+	}
+	// hyphens, digits without a letter and other symbols are not spelled
+	*word = (*word)[1:]
+	return nil
+}
@@ -0,0 +1,51 @@
+package quransearch
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExpandArabizi(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"rahim", "رحيم"},
+		{"ar-rahman", "الرحمن"},
+		{"al-kitab", "الكتاب"},
+		{"3alim", "عليم"},
+		{"jannah", "جنة"},
+		{"moussa", "موسى"},
+	}
+	for _, tt := range tests {
+		candidates := ExpandArabizi(tt.query)
+		if len(candidates) > MAX_ARABIZI_CANDIDATES {
+			t.Errorf("ExpandArabizi(%q) gave %d candidates", tt.query, len(candidates))
+		}
+		if !slices.Contains(candidates, tt.want) {
+			t.Errorf("ExpandArabizi(%q) misses %s", tt.query, tt.want)
+		}
+	}
+	if got := ExpandArabizi("-"); got != nil {
+		t.Errorf("ExpandArabizi(\"-\") = %v", got)
+	}
+}
+
+func TestSearchArabizi(t *testing.T) {
+	qs := newTestSearch(t)
+	for _, c := range qs.ArabiziCandidates("ar-rahman ar-rahim") {
+		if qs.Count(c) == 0 {
+			t.Errorf("candidate %s is not in the text", c)
+		}
+	}
+
+	results := qs.SearchArabizi("ar-rahman ar-rahim", -1)
+	if len(results) == 0 {
+		t.Fatal("no result for ar-rahman ar-rahim")
+	}
+	if r := results[0]; r.StrBld.String()[r.Indexes[0]:r.Indexes[0]+r.Lens[0]] != "الرحمن الرحيم" {
+		t.Errorf("the best result highlights %q", r.StrBld.String()[r.Indexes[0]:r.Indexes[0]+r.Lens[0]])
+	}
+	if results := qs.SearchArabizi("xxx", -1); len(results) != 0 {
+		t.Errorf("xxx found %d results", len(results))
+	}
+}
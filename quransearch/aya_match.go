@@ -151,6 +151,7 @@ func NewAyaMatch(quran string, ayaBegin bool, match SearchMatch, matchLen int) *
 	}
 
 	am.Indexes = append(am.Indexes, oc)
+	am.Lens = append(am.Lens, matchLen)
 	return am
 }
 
@@ -171,6 +172,24 @@ func (am *AyaMatch) setNbrOfPreSpaces(quran string) {
 // AddOccurrence Add a new occurrence index
 func (am *AyaMatch) AddOccurrence(next int) {
 	am.Indexes = append(am.Indexes, next)
+	am.Lens = append(am.Lens, am.MLen)
+}
+
+// AddSpan Add another match of the same aya, given by its index in the quran
+// text and its length
+func (am *AyaMatch) AddSpan(index, length int) {
+	am.Indexes = append(am.Indexes, am.Indexes[0]+index-am.Nfo.Index)
+	am.Lens = append(am.Lens, length)
+}
+
+// AppendAya Append the text between begin and end of the next aya, with a
+// match at index of the given length in it
+func (am *AyaMatch) AppendAya(quran string, begin, end, index, length int) {
+	am.StrBld.WriteString(" ")
+	am.Indexes = append(am.Indexes, am.Len+1+index-begin)
+	am.Lens = append(am.Lens, length)
+	am.StrBld.WriteString(quran[begin:end])
+	am.Len += 1 + end - begin
 }
 
 // AppendNumber Append a suffix to the aya text
@@ -180,6 +199,23 @@ func (am *AyaMatch) AppendNumber(suffix string) {
 	am.Len += am.SLen
 }
 
+// ayaText Return the text of the aya without the dots of a cut aya and the
+// suffix appended to it, whose '.' and '(' are letters and marks in Buckwalter
+func (am *AyaMatch) ayaText() string {
+	s := am.StrBld.String()
+	return strings.TrimPrefix(s[:len(s)-am.SLen], dotsPrefix)
+}
+
+// Buckwalter Return the aya text transliterated to Buckwalter
+func (am *AyaMatch) Buckwalter() string {
+	return ToBuckwalter(am.ayaText())
+}
+
+// SafeBuckwalter Return the aya text transliterated to Safe Buckwalter
+func (am *AyaMatch) SafeBuckwalter() string {
+	return ToSafeBuckwalter(am.ayaText())
+}
+
 // BuildUthmaniRegEx Build a Uthmani regex pattern
 func (am *AyaMatch) BuildUthmaniRegEx() string {
 	var b strings.Builder
@@ -0,0 +1,109 @@
+package quransearch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewAyaTable Build the offset table of every aya line of the quran text
+func NewAyaTable(quran string) *AyaTable {
+	at := &AyaTable{Ayat: make([]AyaOffset, 0, 6236)}
+
+	for line := 0; line < len(quran); {
+		end := strings.IndexByte(quran[line:], '\n')
+		if end == -1 {
+			end = len(quran)
+		} else {
+			end += line
+		}
+
+		ao := AyaOffset{Line: line, Begin: line, End: end}
+		if n := strings.IndexByte(quran[line:end], '|'); n != -1 {
+			ao.Surah, _ = strconv.Atoi(quran[line : line+n])
+			next := line + n + 1
+			if n = strings.IndexByte(quran[next:end], '|'); n != -1 {
+				ao.Aya, _ = strconv.Atoi(quran[next : next+n])
+				ao.Begin = next + n + 1
+			}
+		}
+		ao.setWords(quran)
+
+		at.Ayat = append(at.Ayat, ao)
+		line = end + 1
+	}
+
+	return at
+}
+
+func (ao *AyaOffset) setWords(quran string) {
+	ao.Words = []int{ao.Begin}
+	for i := ao.Begin; i < ao.End; i++ {
+		if quran[i] == ' ' {
+			ao.Words = append(ao.Words, i+1)
+		}
+	}
+}
+
+// Find Return the position in Ayat of the aya line holding the index i
+func (at *AyaTable) Find(i int) int {
+	return sort.Search(len(at.Ayat), func(n int) bool {
+		return at.Ayat[n].Line > i
+	}) - 1
+}
+
+// Lookup Return the position in Ayat of the given surah and aya numbers
+func (at *AyaTable) Lookup(surah, aya int) (int, bool) {
+	n := sort.Search(len(at.Ayat), func(n int) bool {
+		a := at.Ayat[n]
+		return a.Surah > surah || (a.Surah == surah && a.Aya >= aya)
+	})
+	if n < len(at.Ayat) && at.Ayat[n].Surah == surah && at.Ayat[n].Aya == aya {
+		return n, true
+	}
+	return n, false
+}
+
+// WordAt Return the position of the word holding the index i in the aya
+func (ao *AyaOffset) WordAt(i int) int {
+	n := sort.SearchInts(ao.Words, i+1) - 1
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// NewSearchMatch Resolve the index i into a SearchMatch using the table
+func (at *AyaTable) NewSearchMatch(i int, t time.Duration) *SearchMatch {
+	sm := &SearchMatch{
+		Index: i,
+		Time:  t,
+	}
+
+	n := at.Find(i)
+	if n < 0 {
+		return sm
+	}
+	ao := &at.Ayat[n]
+
+	sm.Surah = ao.Surah
+	sm.Aya = ao.Aya
+	sm.Begin = ao.Begin
+	sm.End = ao.End
+	sm.Word = ao.Begin
+	if i > ao.Begin {
+		sm.Word = ao.Words[ao.WordAt(i)]
+	}
+
+	return sm
+}
+
+// newMatch Resolve the index i with the table when there is one, falling
+// back to scanning the text otherwise
+func newMatch(table *AyaTable, text string, i int, t time.Duration) *SearchMatch {
+	if table != nil {
+		return table.NewSearchMatch(i, t)
+	}
+	return NewSearchMatch(text, i, t)
+}
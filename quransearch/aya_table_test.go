@@ -0,0 +1,76 @@
+package quransearch
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+const testQuranPath = "../data/quran.txt"
+
+// newTestSearch Load the simple script text the tests search
+func newTestSearch(t testing.TB) *QuranSearch {
+	t.Helper()
+	qs, err := NewQuranSearch(testQuranPath)
+	if err != nil {
+		t.Fatalf("NewQuranSearch: %v", err)
+	}
+	return qs
+}
+
+func TestAyaTableNewSearchMatch(t *testing.T) {
+	qs := newTestSearch(t)
+	table := NewAyaTable(qs.Quran)
+
+	if len(table.Ayat) != 6236 {
+		t.Fatalf("got %d ayat, want 6236", len(table.Ayat))
+	}
+
+	// the first and last bytes of some ayat, then a spread of offsets
+	offsets := []int{table.Ayat[0].Begin, table.Ayat[6235].Begin, table.Ayat[6235].End - 1}
+	for _, ao := range table.Ayat[:10] {
+		offsets = append(offsets, ao.Begin, ao.Begin+1, ao.End-1)
+	}
+	for i := 0; i < len(qs.Quran); i += 997 {
+		offsets = append(offsets, i)
+	}
+
+	for _, i := range offsets {
+		if !utf8.RuneStart(qs.Quran[i]) {
+			continue
+		}
+		n := table.Find(i)
+		if i < table.Ayat[n].Begin || i >= table.Ayat[n].End {
+			// NewSearchMatch only resolves offsets of the aya text
+			continue
+		}
+		got, want := table.NewSearchMatch(i, 0), NewSearchMatch(qs.Quran, i, 0)
+		if *got != *want {
+			t.Errorf("offset %d: got %+v, want %+v", i, *got, *want)
+		}
+	}
+}
+
+func TestAyaTableLookup(t *testing.T) {
+	table := newTestSearch(t).Ayat
+	tests := []struct {
+		surah, aya int
+		found      bool
+	}{
+		{1, 1, true},
+		{2, 255, true},
+		{2, 287, false},
+		{114, 6, true},
+		{115, 1, false},
+	}
+	for _, tt := range tests {
+		n, ok := table.Lookup(tt.surah, tt.aya)
+		if ok != tt.found {
+			t.Errorf("Lookup(%d, %d) found %v, want %v", tt.surah, tt.aya, ok, tt.found)
+			continue
+		}
+		if ok && (table.Ayat[n].Surah != tt.surah || table.Ayat[n].Aya != tt.aya) {
+			t.Errorf("Lookup(%d, %d) = %d, holding %d:%d", tt.surah, tt.aya, n,
+				table.Ayat[n].Surah, table.Ayat[n].Aya)
+		}
+	}
+}
@@ -2,13 +2,16 @@ package quransearch
 
 import (
 	"time"
-	"unicode/utf8"
 )
 
-// Search searches for occurrences of the pattern in the given text
+// Search searches for occurrences of the pattern in the given text.
+//
+// The tables work on bytes: a valid UTF-8 pattern can only match a valid
+// UTF-8 text on a rune boundary, so Index is a byte offset like the other
+// methods report.
 func (bm *BoyerMooreMethod) Search(text, pattern string, max int) []SearchMatch {
 	bm.Pattern = pattern
-	bm.PatternLength = utf8.RuneCountInString(pattern)
+	bm.PatternLength = len(pattern)
 	bm.BadCharacter = bm.makeBadCharacterShifts()
 	bm.GoodSuffix = bm.makeGoodSuffixShifts()
 
@@ -17,13 +20,13 @@ func (bm *BoyerMooreMethod) Search(text, pattern string, max int) []SearchMatch
 		return matches
 	}
 
-	if max <= 0 {
+	if max < 0 {
 		max = int(^uint(0) >> 1) // Max value for int
 	}
 
 	start := time.Now()
 
-	textLength := utf8.RuneCountInString(text)
+	textLength := len(text)
 	i := bm.PatternLength - 1
 
 	for i < textLength && len(matches) < max {
@@ -33,9 +36,10 @@ func (bm *BoyerMooreMethod) Search(text, pattern string, max int) []SearchMatch
 			j--
 		}
 		if j < 0 {
-			match := NewSearchMatch(text, i+1, time.Since(start))
+			match := newMatch(bm.Table, text, i+1, time.Since(start))
 			matches = append(matches, *match)
-			i += bm.PatternLength * 2
+			// next window ends one byte after this one, to keep overlaps
+			i += bm.PatternLength + 1
 		} else {
 			i += maxInt(bm.GoodSuffix[bm.PatternLength-1-j], bm.BadCharacter[text[i]])
 		}
@@ -45,7 +49,7 @@ func (bm *BoyerMooreMethod) Search(text, pattern string, max int) []SearchMatch
 }
 
 func (bm *BoyerMooreMethod) makeBadCharacterShifts() []int {
-	const AlphabetSize = 'ي' + 1
+	const AlphabetSize = 256
 
 	badCS := make([]int, AlphabetSize)
 	for i := 0; i < AlphabetSize; i++ {
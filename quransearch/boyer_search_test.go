@@ -0,0 +1,36 @@
+package quransearch
+
+import "testing"
+
+func TestBoyerMooreMethodEquivalence(t *testing.T) {
+	testEquivalence(t, func(table *AyaTable) SearchMethod {
+		return &BoyerMooreMethod{Table: table}
+	})
+}
+
+func TestBoyerMooreRuneBoundaries(t *testing.T) {
+	// ل is 0xD9 0x84 and ة 0xD8 0xA9: no byte of one may start a match of
+	// the other half way through a letter
+	text := "1|1|لة ةل\n"
+	tests := []struct {
+		pattern string
+		want    []int
+	}{
+		{"ة", []int{6, 9}},
+		{"ل", []int{4, 11}},
+		{"ةل", []int{9}},
+		{"ق", nil},
+	}
+	for _, tt := range tests {
+		matches := (&BoyerMooreMethod{}).Search(text, tt.pattern, -1)
+		if len(matches) != len(tt.want) {
+			t.Errorf("%q: got %d matches, want %d", tt.pattern, len(matches), len(tt.want))
+			continue
+		}
+		for i, m := range matches {
+			if m.Index != tt.want[i] {
+				t.Errorf("%q: match %d at %d, want %d", tt.pattern, i, m.Index, tt.want[i])
+			}
+		}
+	}
+}
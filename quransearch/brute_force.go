@@ -30,7 +30,7 @@ func (b *BruteForceMethod) Search(text, pattern string, max int) []SearchMatch {
 		}
 		if found {
 			elapsed := time.Since(start)
-			match := NewSearchMatch(text, i, elapsed)
+			match := newMatch(b.Table, text, i, elapsed)
 			matches = append(matches, *match)
 			start = time.Now()
 		}
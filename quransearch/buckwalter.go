@@ -0,0 +1,125 @@
+package quransearch
+
+import (
+	"strings"
+)
+
+const (
+	BUCKWALTER_PREFIX      = "bw:"
+	SAFE_BUCKWALTER_PREFIX = "sbw:"
+)
+
+// buckwalter the Buckwalter letters and diacritics with the Quranic marks of
+// the Quranic Arabic Corpus, then the other marks of quranicMarks on unused
+// ASCII characters so Uthmani text round-trips
+var buckwalter = map[rune]byte{
+	'ء': '\'', 'آ': '|', 'أ': '>', 'ؤ': '&', 'إ': '<', 'ئ': '}', 'ا': 'A',
+	'ب': 'b', 'ة': 'p', 'ت': 't', 'ث': 'v', 'ج': 'j', 'ح': 'H', 'خ': 'x',
+	'د': 'd', 'ذ': '*', 'ر': 'r', 'ز': 'z', 'س': 's', 'ش': '$', 'ص': 'S',
+	'ض': 'D', 'ط': 'T', 'ظ': 'Z', 'ع': 'E', 'غ': 'g', 'ـ': '_', 'ف': 'f',
+	'ق': 'q', 'ك': 'k', 'ل': 'l', 'م': 'm', 'ن': 'n', 'ه': 'h', 'و': 'w',
+	'ى': 'Y', 'ي': 'y',
+	'ً': 'F', 'ٌ': 'N', 'ٍ': 'K', 'َ': 'a', 'ُ': 'u', 'ِ': 'i', 'ّ': '~',
+	'ْ': 'o', 'ٓ': '^', 'ٔ': '#', 'ٰ': '`', 'ٱ': '{',
+	'ۜ': ':', '۟': '@', '۠': '"', 'ۢ': '[', 'ۣ': ';',
+	'ۥ': ',', 'ۦ': '.', 'ۨ': '!', '۪': '-', '۫': '+',
+	'۬': '%', 'ۭ': ']',
+	// not in the corpus scheme
+	'ۡ': 'R', 'ٕ': 'J', 'ٖ': 'U', 'ٗ': 'P', 'ٞ': 'G',
+	'ٜ': 'X', 'ۧ': 'B', 'ۤ': '=', 'ۖ': '(', 'ۗ': ')',
+	'ۘ': '/', 'ۙ': '\\', 'ۚ': '?',
+	'ۛ': 'W', '۞': 'O', '۩': 'Q', '\u200d': 'M',
+}
+
+// safeBuckwalter the changes of Safe Buckwalter, which trades the symbols of
+// the letters for unused ASCII letters; the four marks that sat on those
+// letters take the freed symbols
+var safeBuckwalter = map[rune]byte{
+	'ء': 'C', 'آ': 'M', 'أ': 'O', 'ؤ': 'W', 'إ': 'I', 'ئ': 'Q', 'ذ': 'V',
+	'ش': 'c', 'ٱ': 'L', 'ٰ': 'e',
+	'ۛ': '&', '۞': '>', '۩': '}', '\u200d': '|',
+}
+
+// transliteration one direction of a scheme and its reverse
+type transliteration struct {
+	to   map[rune]byte
+	from map[byte]rune
+}
+
+var (
+	buckwalterScheme     = newTransliteration(buckwalter, nil)
+	safeBuckwalterScheme = newTransliteration(buckwalter, safeBuckwalter)
+)
+
+func newTransliteration(table, changes map[rune]byte) *transliteration {
+	tr := &transliteration{to: make(map[rune]byte), from: make(map[byte]rune)}
+	for r, c := range table {
+		tr.to[r] = c
+	}
+	for r, c := range changes {
+		tr.to[r] = c
+	}
+	for r, c := range tr.to {
+		tr.from[c] = r
+	}
+	return tr
+}
+
+func (tr *transliteration) encode(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if c, ok := tr.to[r]; ok {
+			b.WriteByte(c)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func (tr *transliteration) decode(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) * 2)
+	for _, r := range s {
+		if r < 0x80 {
+			if a, ok := tr.from[byte(r)]; ok {
+				b.WriteRune(a)
+				continue
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ToBuckwalter Transliterate Arabic text to Buckwalter
+func ToBuckwalter(s string) string {
+	return buckwalterScheme.encode(s)
+}
+
+// FromBuckwalter Transliterate Buckwalter back to Arabic text
+func FromBuckwalter(s string) string {
+	return buckwalterScheme.decode(s)
+}
+
+// ToSafeBuckwalter Transliterate Arabic text to Safe Buckwalter
+func ToSafeBuckwalter(s string) string {
+	return safeBuckwalterScheme.encode(s)
+}
+
+// FromSafeBuckwalter Transliterate Safe Buckwalter back to Arabic text
+func FromSafeBuckwalter(s string) string {
+	return safeBuckwalterScheme.decode(s)
+}
+
+// fromTransliteration Return the Arabic query of a "bw:" or "sbw:" query
+func fromTransliteration(p string) (string, bool) {
+	if q, ok := strings.CutPrefix(p, BUCKWALTER_PREFIX); ok {
+		return FromBuckwalter(q), true
+	}
+	if q, ok := strings.CutPrefix(p, SAFE_BUCKWALTER_PREFIX); ok {
+		return FromSafeBuckwalter(q), true
+	}
+	return p, false
+}
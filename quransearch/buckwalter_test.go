@@ -0,0 +1,54 @@
+package quransearch
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestBuckwalterRoundTrip(t *testing.T) {
+	qs := newTestXMLSearch(t)
+	schemes := []struct {
+		name     string
+		to, from func(string) string
+	}{
+		{"Buckwalter", ToBuckwalter, FromBuckwalter},
+		{"Safe Buckwalter", ToSafeBuckwalter, FromSafeBuckwalter},
+	}
+	for _, scheme := range schemes {
+		for _, ao := range qs.Ayat.Ayat {
+			aya := qs.Quran[ao.Begin:ao.End]
+			encoded := scheme.to(aya)
+			if i := strings.IndexFunc(encoded, func(r rune) bool { return r >= utf8.RuneSelf }); i >= 0 {
+				r, _ := utf8.DecodeRuneInString(encoded[i:])
+				t.Fatalf("%s: %d:%d keeps %q (%U)", scheme.name, ao.Surah, ao.Aya, r, r)
+			}
+			if got := scheme.from(encoded); got != aya {
+				t.Fatalf("%s: %d:%d does not round-trip:\n%s\n%s", scheme.name, ao.Surah, ao.Aya, got, aya)
+			}
+		}
+	}
+}
+
+func TestAyaMatchBuckwalter(t *testing.T) {
+	qs := newTestXMLSearch(t)
+	n, _ := qs.Ayat.Lookup(1, 7)
+	ao := &qs.Ayat.Ayat[n]
+
+	// an aya shown from its third word, with its number appended
+	match := qs.Ayat.NewSearchMatch(ao.Words[2], time.Duration(0))
+	am := NewAyaMatch(qs.Quran, false, *match, len(ao.word(qs.Quran, 2)))
+	am.AppendNumber(" (7)")
+	if !strings.HasPrefix(am.StrBld.String(), dotsPrefix) {
+		t.Fatalf("the aya is not cut: %s", am.StrBld.String())
+	}
+
+	want := qs.Quran[ao.Words[2]:ao.End]
+	if got := FromBuckwalter(am.Buckwalter()); got != want {
+		t.Errorf("Buckwalter() = %s, reads back as\n%s\nwant\n%s", am.Buckwalter(), got, want)
+	}
+	if got := FromSafeBuckwalter(am.SafeBuckwalter()); got != want {
+		t.Errorf("SafeBuckwalter() = %s, reads back as\n%s\nwant\n%s", am.SafeBuckwalter(), got, want)
+	}
+}
@@ -0,0 +1,138 @@
+package quransearch
+
+import (
+	"time"
+)
+
+// nearLetters groups of letters often confused for one another, a
+// substitution inside a group costs half an edit
+var nearLetters = []string{
+	"سصث",
+	"تطة",
+	"دضذ",
+	"زظذ",
+	"هحة",
+	"ىيئ",
+	"اأإآٱء",
+	"ؤوء",
+	"كق",
+	"غع",
+}
+
+const (
+	fullEditCost = 1.0
+	nearEditCost = 0.5
+)
+
+// nearGroups bit set of the nearLetters groups of each letter of the
+// Arabic block, indexed from U+0600
+var nearGroups = func() (groups [0x100]uint32) {
+	for n, group := range nearLetters {
+		for _, c := range group {
+			groups[c-0x600] |= 1 << n
+		}
+	}
+	return groups
+}()
+
+// ArabicSubstitutionCost Return the cost of substituting the letter b for a
+func ArabicSubstitutionCost(a, b rune) float64 {
+	if a == b {
+		return 0
+	}
+	if a >= 0x600 && a < 0x700 && b >= 0x600 && b < 0x700 &&
+		nearGroups[a-0x600]&nearGroups[b-0x600] != 0 {
+		return nearEditCost
+	}
+	return fullEditCost
+}
+
+// fuzzyHit candidate match, in runes of the aya text
+type fuzzyHit struct {
+	begin, end int
+	dist       float64
+}
+
+// Search finds the aya substrings within MaxDistance edits of the pattern
+// (Sellers' algorithm), each match carrying its distance
+func (fm *FuzzyMethod) Search(text, pattern string, max int) []SearchMatch {
+	start := time.Now()
+	matches := make([]SearchMatch, 0)
+
+	p := []rune(pattern)
+	if len(p) == 0 || max == 0 {
+		return matches
+	}
+	if fm.Table == nil {
+		fm.Table = NewAyaTable(text)
+	}
+	cost := fm.Cost
+	if cost == nil {
+		cost = ArabicSubstitutionCost
+	}
+
+	for _, ao := range fm.Table.Ayat {
+		aya := text[ao.Begin:ao.End]
+		offsets := runeOffsets(aya)
+		for _, hit := range fm.ayaHits(p, []rune(aya), cost) {
+			match := newMatch(fm.Table, text, ao.Begin+offsets[hit.begin], time.Since(start))
+			match.Length = offsets[hit.end] - offsets[hit.begin]
+			match.Distance = hit.dist
+			matches = append(matches, *match)
+			if max > 0 && len(matches) >= max {
+				return matches
+			}
+		}
+	}
+
+	return matches
+}
+
+// ayaHits Run the edit distance columns over the aya and keep the best hit
+// of every run of overlapping candidates
+func (fm *FuzzyMethod) ayaHits(p, t []rune, cost func(a, b rune) float64) []fuzzyHit {
+	m := len(p)
+	dist := make([]float64, m+1)
+	from := make([]int, m+1)
+	next := make([]float64, m+1)
+	nextFrom := make([]int, m+1)
+	for j := range dist {
+		dist[j] = float64(j) * fullEditCost
+	}
+
+	var hits []fuzzyHit
+	for k, c := range t {
+		next[0], nextFrom[0] = 0, k+1
+		for j := 1; j <= m; j++ {
+			next[j], nextFrom[j] = dist[j-1]+cost(p[j-1], c), from[j-1]
+			if d := dist[j] + fullEditCost; d < next[j] {
+				next[j], nextFrom[j] = d, from[j]
+			}
+			if d := next[j-1] + fullEditCost; d < next[j] {
+				next[j], nextFrom[j] = d, nextFrom[j-1]
+			}
+		}
+		if next[m] <= fm.MaxDistance && nextFrom[m] <= k {
+			hit := fuzzyHit{begin: nextFrom[m], end: k + 1, dist: next[m]}
+			if n := len(hits) - 1; n >= 0 && hit.begin < hits[n].end {
+				if hit.dist < hits[n].dist {
+					hits[n] = hit
+				}
+			} else {
+				hits = append(hits, hit)
+			}
+		}
+		dist, next = next, dist
+		from, nextFrom = nextFrom, from
+	}
+	return hits
+}
+
+// runeOffsets Return the byte offset of every rune of s, and len(s) last
+func runeOffsets(s string) []int {
+	offsets := make([]int, 0, len(s)+1)
+	for i := range s {
+		offsets = append(offsets, i)
+	}
+	return append(offsets, len(s))
+}
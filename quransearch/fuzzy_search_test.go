@@ -0,0 +1,76 @@
+package quransearch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArabicSubstitutionCost(t *testing.T) {
+	tests := []struct {
+		a, b rune
+		want float64
+	}{
+		{'ط', 'ط', 0},
+		{'ط', 'ت', nearEditCost},
+		{'ص', 'س', nearEditCost},
+		{'ق', 'ك', nearEditCost},
+		{'أ', 'ا', nearEditCost},
+		{'ب', 'ت', fullEditCost},
+		{'a', 'b', fullEditCost},
+	}
+	for _, tt := range tests {
+		if got := ArabicSubstitutionCost(tt.a, tt.b); got != tt.want {
+			t.Errorf("ArabicSubstitutionCost(%c, %c) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzyMethodSearch(t *testing.T) {
+	qs := newTestSearch(t)
+	want := strings.Count(qs.Quran, "الصراط")
+
+	tests := []struct {
+		pattern  string
+		maxDist  float64
+		distance float64
+		count    int
+	}{
+		{"الصراط", 0, 0, want},
+		{"الصرات", 0.5, nearEditCost, want},
+		{"الصرات", 0.4, 0, 0},
+		{"الصاط", 1, fullEditCost, want},
+		{"الصاط", 0.5, 0, 0},
+	}
+	for _, tt := range tests {
+		fm := &FuzzyMethod{MaxDistance: tt.maxDist, Table: qs.Ayat}
+		matches := fm.Search(qs.Quran, tt.pattern, -1)
+		count := 0
+		for _, m := range matches {
+			if qs.Quran[m.Index:m.Index+m.Length] != "الصراط" {
+				continue
+			}
+			count++
+			if m.Distance != tt.distance {
+				t.Errorf("%s within %v: distance %v, want %v", tt.pattern, tt.maxDist, m.Distance, tt.distance)
+			}
+		}
+		if count != tt.count {
+			t.Errorf("%s within %v: %d matches of الصراط, want %d", tt.pattern, tt.maxDist, count, tt.count)
+		}
+		for _, m := range matches {
+			if m.Distance > tt.maxDist {
+				t.Errorf("%s within %v: match at distance %v", tt.pattern, tt.maxDist, m.Distance)
+			}
+		}
+	}
+}
+
+func TestFuzzySearch(t *testing.T) {
+	qs := newTestSearch(t)
+	if results := qs.FuzzySearch("الصرات المستقيم", 0.5, -1); len(results) == 0 {
+		t.Error("الصرات المستقيم found nothing within half an edit")
+	}
+	if results := qs.FuzzySearch("الصرات المستقيم", 0, -1); len(results) != 0 {
+		t.Errorf("الصرات المستقيم found %d ayat as an exact match", len(results))
+	}
+}
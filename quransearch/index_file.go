@@ -0,0 +1,319 @@
+package quransearch
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	INDEX_FORMAT_VERSION = 2
+	indexMagic           = "QSIX"
+)
+
+var (
+	ErrIndexFormat   = errors.New("not a quran search index")
+	ErrIndexVersion  = errors.New("unsupported index format version")
+	ErrIndexChecksum = errors.New("index was built from a different quran text")
+)
+
+// NewQuranSearchFromIndex Constructor loading the aya offsets, the word
+// index and the suffix array from an index file written by WriteIndexFile
+// instead of building them
+func NewQuranSearchFromIndex(filePath, indexPath string) (*QuranSearch, error) {
+	qs := &QuranSearch{CurrentMethod: METHOD_DEFAULT, FoldVariants: true}
+	if err := qs.readFile(filePath); err != nil {
+		return nil, fmt.Errorf("NewQuranSearchFromIndex: %v", err)
+	}
+
+	file, err := os.Open(indexPath)
+	if err != nil {
+		return nil, fmt.Errorf("NewQuranSearchFromIndex: %v", err)
+	}
+	defer file.Close()
+
+	index, err := ReadIndex(bufio.NewReader(file), qs.Quran)
+	if err != nil {
+		return nil, fmt.Errorf("NewQuranSearchFromIndex: %s: %w", indexPath, err)
+	}
+	qs.Ayat, qs.Words, qs.Suffixes = index.Ayat, index.Words, index.Suffixes
+	return qs, nil
+}
+
+// WriteIndexFile Write the index of the loaded text to path, building the
+// word index and the suffix array when they are not built yet
+func (qs *QuranSearch) WriteIndexFile(path string) error {
+	index := &QuranIndex{Ayat: qs.Ayat, Words: qs.Words, Suffixes: qs.Suffixes}
+	if index.Words == nil {
+		index.Words = NewWordIndex(qs.Quran, qs.Ayat)
+	}
+	if index.Suffixes == nil {
+		index.Suffixes = NewSuffixArray(qs.Quran)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("WriteIndexFile: %v", err)
+	}
+	w := bufio.NewWriter(file)
+	if err = WriteIndex(w, qs.Quran, index); err == nil {
+		err = w.Flush()
+	}
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("WriteIndexFile: %v", err)
+	}
+	return nil
+}
+
+// WriteIndex Serialize the tables of quran to w.
+//
+// The layout is the "QSIX" magic, the format version, the sha256 of the text
+// and its length, then per aya its numbers and offsets as uvarints, each
+// offset stored as a delta from the previous one. The word index follows as
+// its sorted vocabulary and, for every word of the text, the number of its
+// entry counted from 1 (0 for a word left out), then the suffix array as
+// little endian uint32. The LCP array is rebuilt when loading.
+func WriteIndex(w io.Writer, quran string, index *QuranIndex) error {
+	sum := sha256.Sum256([]byte(quran))
+	table := index.Ayat
+
+	buf := []byte(indexMagic)
+	buf = binary.AppendUvarint(buf, INDEX_FORMAT_VERSION)
+	buf = append(buf, sum[:]...)
+	buf = binary.AppendUvarint(buf, uint64(len(quran)))
+	buf = binary.AppendUvarint(buf, uint64(len(table.Ayat)))
+
+	prev := 0
+	for _, ao := range table.Ayat {
+		buf = binary.AppendUvarint(buf, uint64(ao.Surah))
+		buf = binary.AppendUvarint(buf, uint64(ao.Aya))
+		buf = binary.AppendUvarint(buf, uint64(ao.Line-prev))
+		buf = binary.AppendUvarint(buf, uint64(ao.Begin-ao.Line))
+		buf = binary.AppendUvarint(buf, uint64(ao.End-ao.Begin))
+		buf = binary.AppendUvarint(buf, uint64(len(ao.Words)))
+		last := ao.Begin
+		for _, word := range ao.Words {
+			buf = binary.AppendUvarint(buf, uint64(word-last))
+			last = word
+		}
+		prev = ao.End
+	}
+
+	// the entry of every word of every aya, from the postings
+	entries := make([][]int, len(table.Ayat))
+	for n, ao := range table.Ayat {
+		entries[n] = make([]int, len(ao.Words))
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(index.Words.Words)))
+	for k, word := range index.Words.Words {
+		buf = binary.AppendUvarint(buf, uint64(len(word)))
+		buf = append(buf, word...)
+		for _, p := range index.Words.Postings[word] {
+			entries[table.Find(p.Offset)][p.Pos] = k + 1
+		}
+	}
+	for _, words := range entries {
+		for _, k := range words {
+			buf = binary.AppendUvarint(buf, uint64(k))
+		}
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(index.Suffixes.SA)))
+	for _, s := range index.Suffixes.SA {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(s))
+	}
+
+	_, err := w.Write(buf)
+	return err
+}
+
+// ReadIndex Load the tables written by WriteIndex, checking they were built
+// from quran
+func ReadIndex(r io.ByteReader, quran string) (*QuranIndex, error) {
+	magic := make([]byte, len(indexMagic))
+	for i := range magic {
+		c, err := r.ReadByte()
+		if err != nil {
+			return nil, ErrIndexFormat
+		}
+		magic[i] = c
+	}
+	if string(magic) != indexMagic {
+		return nil, ErrIndexFormat
+	}
+
+	ir := indexReader{r: r}
+	if version := ir.next(); ir.err == nil && version != INDEX_FORMAT_VERSION {
+		return nil, fmt.Errorf("%w %d, want %d", ErrIndexVersion, version, INDEX_FORMAT_VERSION)
+	}
+
+	var sum [sha256.Size]byte
+	for i := range sum {
+		sum[i] = ir.byte()
+	}
+	size := ir.next()
+	if ir.err != nil {
+		return nil, ir.err
+	}
+	if want := sha256.Sum256([]byte(quran)); !bytes.Equal(sum[:], want[:]) || size != len(quran) {
+		return nil, ErrIndexChecksum
+	}
+	// no count, offset or length of the file is larger than the text
+	ir.limit = len(quran)
+
+	at, err := ir.ayaTable(quran)
+	if err != nil {
+		return nil, err
+	}
+	wi, err := ir.wordIndex(at)
+	if err != nil {
+		return nil, err
+	}
+	sa, err := ir.suffixArray(quran)
+	if err != nil {
+		return nil, err
+	}
+	return &QuranIndex{Ayat: at, Words: wi, Suffixes: sa}, nil
+}
+
+func (ir *indexReader) ayaTable(quran string) (*AyaTable, error) {
+	count := ir.next()
+	at := &AyaTable{Ayat: make([]AyaOffset, 0, count)}
+	prev := 0
+	for n := 0; n < count && ir.err == nil; n++ {
+		ao := AyaOffset{Surah: ir.next(), Aya: ir.next()}
+		ao.Line = prev + ir.next()
+		ao.Begin = ao.Line + ir.next()
+		ao.End = ao.Begin + ir.next()
+		words := ir.next()
+		if ir.err != nil || ao.End > len(quran) || words > ao.End-ao.Begin+1 {
+			return nil, fmt.Errorf("%w: corrupt aya %d", ErrIndexFormat, n)
+		}
+		ao.Words = make([]int, words)
+		last := ao.Begin
+		for i := range ao.Words {
+			last += ir.next()
+			if last > ao.End {
+				return nil, fmt.Errorf("%w: corrupt aya %d", ErrIndexFormat, n)
+			}
+			ao.Words[i] = last
+		}
+		at.Ayat = append(at.Ayat, ao)
+		prev = ao.End
+	}
+	if ir.err != nil {
+		return nil, ir.err
+	}
+	return at, nil
+}
+
+func (ir *indexReader) wordIndex(at *AyaTable) (*WordIndex, error) {
+	wi := &WordIndex{Words: make([]string, ir.next())}
+	for k := range wi.Words {
+		wi.Words[k] = string(ir.bytes(ir.next()))
+		if ir.err == nil && k > 0 && wi.Words[k] <= wi.Words[k-1] {
+			return nil, fmt.Errorf("%w: unsorted words", ErrIndexFormat)
+		}
+	}
+
+	postings := make([][]Posting, len(wi.Words))
+	for _, ao := range at.Ayat {
+		for pos, start := range ao.Words {
+			k := ir.next()
+			if k > len(wi.Words) {
+				return nil, fmt.Errorf("%w: corrupt word of %d:%d", ErrIndexFormat, ao.Surah, ao.Aya)
+			}
+			if k > 0 {
+				postings[k-1] = append(postings[k-1], Posting{Surah: ao.Surah, Aya: ao.Aya, Pos: pos, Offset: start})
+			}
+		}
+	}
+	if ir.err != nil {
+		return nil, ir.err
+	}
+
+	wi.Postings = make(map[string][]Posting, len(wi.Words))
+	for k, word := range wi.Words {
+		wi.Postings[word] = postings[k]
+	}
+	return wi, nil
+}
+
+func (ir *indexReader) suffixArray(quran string) (*SuffixArray, error) {
+	if n := ir.next(); ir.err == nil && n != len(quran) {
+		return nil, fmt.Errorf("%w: %d suffixes for %d bytes", ErrIndexFormat, n, len(quran))
+	}
+	sa := &SuffixArray{Text: quran, SA: make([]int32, len(quran))}
+	seen := make([]bool, len(quran))
+	for i := range sa.SA {
+		s := ir.uint32()
+		if ir.err != nil {
+			return nil, ir.err
+		}
+		if int(s) >= len(quran) || seen[s] {
+			return nil, fmt.Errorf("%w: corrupt suffix %d", ErrIndexFormat, i)
+		}
+		seen[s] = true
+		sa.SA[i] = int32(s)
+	}
+	sa.LCP = buildLCP(quran, sa.SA)
+	return sa, nil
+}
+
+// indexReader Read uvarints, keeping the first error
+type indexReader struct {
+	r     io.ByteReader
+	err   error
+	limit int // largest value read once set
+}
+
+func (ir *indexReader) next() int {
+	if ir.err != nil {
+		return 0
+	}
+	v, err := binary.ReadUvarint(ir.r)
+	if err != nil || v > uint64(^uint(0)>>1) {
+		ir.err = fmt.Errorf("%w: truncated", ErrIndexFormat)
+		return 0
+	}
+	if ir.limit > 0 && v > uint64(ir.limit) {
+		ir.err = fmt.Errorf("%w: value %d out of range", ErrIndexFormat, v)
+		return 0
+	}
+	return int(v)
+}
+
+func (ir *indexReader) byte() byte {
+	if ir.err != nil {
+		return 0
+	}
+	c, err := ir.r.ReadByte()
+	if err != nil {
+		ir.err = fmt.Errorf("%w: truncated", ErrIndexFormat)
+	}
+	return c
+}
+
+func (ir *indexReader) bytes(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = ir.byte()
+	}
+	return b
+}
+
+func (ir *indexReader) uint32() uint32 {
+	var b [4]byte
+	for i := range b {
+		b[i] = ir.byte()
+	}
+	return binary.LittleEndian.Uint32(b[:])
+}
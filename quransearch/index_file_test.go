@@ -0,0 +1,121 @@
+package quransearch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTestIndex(t *testing.T, qs *QuranSearch) []byte {
+	t.Helper()
+	index := &QuranIndex{Ayat: qs.Ayat, Words: NewWordIndex(qs.Quran, qs.Ayat), Suffixes: NewSuffixArray(qs.Quran)}
+	var buf bytes.Buffer
+	if err := WriteIndex(&buf, qs.Quran, index); err != nil {
+		t.Fatalf("WriteIndex: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestIndexRoundTrip(t *testing.T) {
+	qs := newTestSearch(t)
+	data := writeTestIndex(t, qs)
+
+	index, err := ReadIndex(bytes.NewReader(data), qs.Quran)
+	if err != nil {
+		t.Fatalf("ReadIndex: %v", err)
+	}
+	if !reflect.DeepEqual(index.Ayat, qs.Ayat) {
+		t.Error("the aya table read differs from the one written")
+	}
+	if !reflect.DeepEqual(index.Words, NewWordIndex(qs.Quran, qs.Ayat)) {
+		t.Error("the word index read differs from the one written")
+	}
+	if !reflect.DeepEqual(index.Suffixes, NewSuffixArray(qs.Quran)) {
+		t.Error("the suffix array read differs from the one written")
+	}
+}
+
+func TestQuranSearchFromIndex(t *testing.T) {
+	qs := newTestSearch(t)
+	path := filepath.Join(t.TempDir(), "quran.idx")
+	if err := qs.WriteIndexFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := NewQuranSearchFromIndex(testQuranPath, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Words == nil || loaded.Suffixes == nil {
+		t.Fatal("the word index and the suffix array were not loaded")
+	}
+	for _, method := range []int{METHOD_DEFAULT, METHOD_WORD_INDEX, METHOD_SUFFIX_ARRAY} {
+		qs.CurrentMethod, loaded.CurrentMethod = method, method
+		if got, want := len(loaded.Search("الرحمن", -1)), len(qs.Search("الرحمن", -1)); got != want {
+			t.Errorf("method %d found %d ayat from the index, %d from the text", method, got, want)
+		}
+	}
+}
+
+func TestIndexChecksum(t *testing.T) {
+	qs := newTestSearch(t)
+	data := writeTestIndex(t, qs)
+
+	other := qs.Quran[:len(qs.Quran)-2] + "X\n"
+	if _, err := ReadIndex(bytes.NewReader(data), other); !errors.Is(err, ErrIndexChecksum) {
+		t.Errorf("an index of another text gave %v, want ErrIndexChecksum", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "quran.txt")
+	if err := os.WriteFile(path, []byte(other), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	index := filepath.Join(t.TempDir(), "quran.idx")
+	if err := os.WriteFile(index, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewQuranSearchFromIndex(path, index); !errors.Is(err, ErrIndexChecksum) {
+		t.Errorf("NewQuranSearchFromIndex gave %v, want ErrIndexChecksum", err)
+	}
+}
+
+func TestIndexCorrupt(t *testing.T) {
+	qs := newTestSearch(t)
+	data := writeTestIndex(t, qs)
+
+	if _, err := ReadIndex(bytes.NewReader([]byte("QSIZ")), qs.Quran); !errors.Is(err, ErrIndexFormat) {
+		t.Errorf("a bad magic gave %v", err)
+	}
+	version := append([]byte(indexMagic), binary.AppendUvarint(nil, INDEX_FORMAT_VERSION+1)...)
+	if _, err := ReadIndex(bytes.NewReader(version), qs.Quran); !errors.Is(err, ErrIndexVersion) {
+		t.Errorf("a newer version gave %v", err)
+	}
+
+	// cut inside the header, the ayat, the words and the suffix array
+	for _, n := range []int{len(indexMagic), 20, 60, len(data) / 40, len(data) / 10, len(data) / 2, len(data) - 1} {
+		if _, err := ReadIndex(bytes.NewReader(data[:n]), qs.Quran); !errors.Is(err, ErrIndexFormat) {
+			t.Errorf("an index cut at %d bytes gave %v", n, err)
+		}
+	}
+
+	// crafted deltas whose sum overflows to an offset inside the text
+	quran := "1|1|ab\n"
+	sum := sha256.Sum256([]byte(quran))
+	crafted := binary.AppendUvarint([]byte(indexMagic), INDEX_FORMAT_VERSION)
+	crafted = append(crafted, sum[:]...)
+	for _, v := range []uint64{uint64(len(quran)), 1, 1, 1, math.MaxInt64, math.MaxInt64, 4, 0, 0, uint64(len(quran))} {
+		crafted = binary.AppendUvarint(crafted, v)
+	}
+	for _, s := range NewSuffixArray(quran).SA {
+		crafted = binary.LittleEndian.AppendUint32(crafted, uint32(s))
+	}
+	if _, err := ReadIndex(bytes.NewReader(crafted), quran); !errors.Is(err, ErrIndexFormat) {
+		t.Errorf("offsets out of range gave %v", err)
+	}
+}
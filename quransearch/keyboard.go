@@ -0,0 +1,69 @@
+package quransearch
+
+import (
+	"strings"
+	"unicode"
+)
+
+// arabicKeyboard the letters of the standard Arabic (101) layout, by the
+// QWERTY key they are on
+var arabicKeyboard = map[rune]string{
+	'q': "ض", 'w': "ص", 'e': "ث", 'r': "ق", 't': "ف", 'y': "غ", 'u': "ع",
+	'i': "ه", 'o': "خ", 'p': "ح", '[': "ج", ']': "د",
+	'a': "ش", 's': "س", 'd': "ي", 'f': "ب", 'g': "ل", 'h': "ا", 'j': "ت",
+	'k': "ن", 'l': "م", ';': "ك", '\'': "ط",
+	'z': "ئ", 'x': "ء", 'c': "ؤ", 'v': "ر", 'b': "لا", 'n': "ى", 'm': "ة",
+	',': "و", '.': "ز", '/': "ظ", '`': "ذ",
+	'Q': "َ", 'W': "ً", 'E': "ُ", 'R': "ٌ", 'T': "لإ", 'Y': "إ",
+	'A': "ِ", 'S': "ٍ", 'G': "لأ", 'H': "أ", 'J': "ـ",
+	'X': "ْ", 'B': "لآ", 'N': "آ", '~': "ّ", '?': "؟",
+}
+
+// azertyKeys the QWERTY key at the place of each AZERTY key that differs
+var azertyKeys = map[rune]rune{
+	'a': 'q', 'z': 'w', 'q': 'a', 'w': 'z', 'm': ';', ',': 'm', ';': ',',
+	':': '.', '!': '/', 'ù': '\'', '^': '[', '$': ']', '²': '`',
+	'A': 'Q', 'Z': 'W', 'Q': 'A', 'W': 'Z',
+}
+
+// FromQwerty Return the Arabic a query typed on a QWERTY layout was meant to
+// be, keys of the Arabic layout replaced by their letters: "hgpl]" for
+// الحمد, the د being on the ] key; "hgpln" is الحمى, the n key giving ى
+func FromQwerty(q string) string {
+	var b strings.Builder
+	b.Grow(len(q) * 2)
+	for _, r := range q {
+		if a, ok := arabicKeyboard[r]; ok {
+			b.WriteString(a)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// FromAzerty Return the Arabic a query typed on an AZERTY layout was meant to
+// be, through the QWERTY key at the same place
+func FromAzerty(q string) string {
+	return FromQwerty(strings.Map(func(r rune) rune {
+		if k, ok := azertyKeys[r]; ok {
+			return k
+		}
+		return r
+	}, q))
+}
+
+// isWrongLayout Check whether a query holds Latin letters and no Arabic,
+// as typed with a Latin layout active
+func isWrongLayout(q string) bool {
+	latin := false
+	for _, r := range q {
+		if unicode.Is(unicode.Arabic, r) {
+			return false
+		}
+		if r < unicode.MaxASCII && unicode.IsLetter(r) {
+			latin = true
+		}
+	}
+	return latin
+}
@@ -0,0 +1,74 @@
+package quransearch
+
+import "testing"
+
+func TestFromQwerty(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"hgpl]", "الحمد"},
+		{"hgpln", "الحمى"},
+		{"fsl hggi", "بسم الله"},
+		{"bvdf", "لاريب"},
+		{"hgHvq", "الأرض"},
+		{"lgm", "ملة"},
+	}
+	for _, tt := range tests {
+		if got := FromQwerty(tt.query); got != tt.want {
+			t.Errorf("FromQwerty(%q) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+
+	if got := FromAzerty("hgpl$ fsl hggi"); got != "الحمد بسم الله" {
+		t.Errorf("FromAzerty = %s", got)
+	}
+}
+
+func TestArabicKeyboard(t *testing.T) {
+	// every letter of the alphabet is on one key
+	keys := map[string]rune{}
+	for key, letters := range arabicKeyboard {
+		if k, ok := keys[letters]; ok {
+			t.Errorf("%s is on both %q and %q", letters, k, key)
+		}
+		keys[letters] = key
+	}
+	for r := 'ء'; r <= 'ي'; r++ {
+		if r >= 'ػ' && r <= 'ـ' {
+			continue
+		}
+		if _, ok := keys[string(r)]; !ok {
+			t.Errorf("%c is on no key", r)
+		}
+	}
+}
+
+func TestSearchWrongLayout(t *testing.T) {
+	qs := newTestSearch(t)
+	want := len(qs.Search("الحمد", -1))
+
+	for _, query := range []string{"hgpl]", "hgpl$"} {
+		results := qs.Search(query, -1)
+		if len(results) != want {
+			t.Errorf("%s found %d ayat, want the %d of الحمد", query, len(results), want)
+		}
+		for _, r := range results {
+			if r.DidYouMean != "الحمد" {
+				t.Fatalf("%s: DidYouMean = %q", query, r.DidYouMean)
+			}
+		}
+	}
+
+	// a query in Arabic is searched as typed, even without hits
+	for _, r := range qs.Search("الحمد", -1) {
+		if r.DidYouMean != "" {
+			t.Fatalf("الحمد: DidYouMean = %q", r.DidYouMean)
+		}
+	}
+	if got := qs.Search("ضصثق", -1); len(got) != 0 {
+		t.Errorf("ضصثق found %d ayat", len(got))
+	}
+	if got := qs.Search("bw:Hmd", 1); len(got) != 1 || got[0].DidYouMean != "" {
+		t.Errorf("a Buckwalter query was remapped: %+v", got)
+	}
+}
@@ -0,0 +1,53 @@
+package quransearch
+
+const (
+	MATCH_ANYWHERE    = 0
+	MATCH_WHOLE_WORD  = 1
+	MATCH_WORD_PREFIX = 2
+	MATCH_WORD_SUFFIX = 3
+	MATCH_INFIX       = 4
+)
+
+// filterMatchMode Keep the matches placed in their words as the mode asks,
+// plen being the matched length when a match does not carry one, up to max
+func filterMatchMode(text string, matches []SearchMatch, plen, mode, max int) []SearchMatch {
+	if mode == MATCH_ANYWHERE {
+		return matches
+	}
+	kept := matches[:0]
+	for _, m := range matches {
+		if max >= 0 && len(kept) >= max {
+			break
+		}
+		end := m.Index + plen
+		if m.Length > 0 {
+			end = m.Index + m.Length
+		}
+		starts, ends := startsWord(text, m.Index), endsWord(text, end)
+
+		var ok bool
+		switch mode {
+		case MATCH_WHOLE_WORD:
+			ok = starts && ends
+		case MATCH_WORD_PREFIX:
+			ok = starts
+		case MATCH_WORD_SUFFIX:
+			ok = ends
+		case MATCH_INFIX:
+			ok = !starts && !ends
+		}
+		if ok {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// limit Return the max to run a method with, all the matches when they are
+// filtered afterwards
+func (qs *QuranSearch) limit(max int) int {
+	if qs.MatchMode != MATCH_ANYWHERE {
+		return -1
+	}
+	return max
+}
@@ -0,0 +1,150 @@
+package quransearch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterMatchMode(t *testing.T) {
+	text := "1|1|كتب الكتاب مكتوب يكتبه\n"
+	var matches []SearchMatch
+	for i := 0; ; {
+		found := strings.Index(text[i:], "كت")
+		if found == -1 {
+			break
+		}
+		i += found
+		matches = append(matches, SearchMatch{Index: i})
+		i++
+	}
+
+	// كتب starts a word, كتاب is inside الكتاب, and so on
+	tests := []struct {
+		mode int
+		want int
+	}{
+		{MATCH_ANYWHERE, 4},
+		{MATCH_WHOLE_WORD, 0},
+		{MATCH_WORD_PREFIX, 1},
+		{MATCH_WORD_SUFFIX, 0},
+		{MATCH_INFIX, 3},
+	}
+	for _, tt := range tests {
+		kept := filterMatchMode(text, append([]SearchMatch(nil), matches...), len("كت"), tt.mode, -1)
+		if len(kept) != tt.want {
+			t.Errorf("mode %d kept %d matches, want %d", tt.mode, len(kept), tt.want)
+		}
+	}
+
+	if kept := filterMatchMode(text, append([]SearchMatch(nil), matches...), len("كت"), MATCH_INFIX, 2); len(kept) != 2 {
+		t.Errorf("max 2 kept %d matches", len(kept))
+	}
+	whole := []SearchMatch{{Index: strings.Index(text, "الكتاب"), Length: len("الكتاب")}}
+	if kept := filterMatchMode(text, whole, 0, MATCH_WHOLE_WORD, -1); len(kept) != 1 {
+		t.Error("the Length of a match was not used to find its end")
+	}
+}
+
+func TestSearchMatchModes(t *testing.T) {
+	qs := newTestSearch(t)
+	const p = "رحم"
+
+	// classify every occurrence as the modes do
+	want := map[int]int{}
+	for i := 0; ; {
+		found := strings.Index(qs.Quran[i:], p)
+		if found == -1 {
+			break
+		}
+		i += found
+		starts, ends := startsWord(qs.Quran, i), endsWord(qs.Quran, i+len(p))
+		want[MATCH_ANYWHERE]++
+		if starts && ends {
+			want[MATCH_WHOLE_WORD]++
+		}
+		if starts {
+			want[MATCH_WORD_PREFIX]++
+		}
+		if ends {
+			want[MATCH_WORD_SUFFIX]++
+		}
+		if !starts && !ends {
+			want[MATCH_INFIX]++
+		}
+		i += len(p)
+	}
+
+	for mode := MATCH_ANYWHERE; mode <= MATCH_INFIX; mode++ {
+		qs.MatchMode = mode
+		all := qs.find(p, -1)
+		if len(all) != want[mode] || want[mode] == 0 {
+			t.Errorf("mode %d found %d matches, want %d", mode, len(all), want[mode])
+			continue
+		}
+
+		// max counts the matches kept, not the ones filtered out
+		first := qs.find(p, 3)
+		if len(first) != 3 {
+			t.Errorf("mode %d with max 3 found %d matches", mode, len(first))
+			continue
+		}
+		for i := range first {
+			if first[i].Index != all[i].Index {
+				t.Errorf("mode %d with max 3: match %d at %d, want %d", mode, i, first[i].Index, all[i].Index)
+			}
+		}
+	}
+}
+
+func TestSearchSpecialCaseMatchModes(t *testing.T) {
+	qs := newTestSearch(t)
+	// حم opens the seven surahs from 40 on
+	tests := []struct {
+		mode, max, ayat int
+	}{
+		{MATCH_ANYWHERE, -1, 7},
+		{MATCH_ANYWHERE, 3, 3},
+		{MATCH_WHOLE_WORD, -1, 7},
+		{MATCH_WHOLE_WORD, 2, 2},
+		{MATCH_WORD_SUFFIX, -1, 7},
+		{MATCH_INFIX, -1, 0},
+		{MATCH_INFIX, 3, 0},
+	}
+	for _, tt := range tests {
+		qs.MatchMode = tt.mode
+		results := qs.Search("حم", tt.max)
+		if len(results) != tt.ayat {
+			t.Errorf("mode %d with max %d found %d ayat, want %d", tt.mode, tt.max, len(results), tt.ayat)
+		}
+		for i, r := range results {
+			if r.Nfo.Surah != 40+i || r.Nfo.Aya != 1 {
+				t.Errorf("mode %d found %d:%d, want %d:1", tt.mode, r.Nfo.Surah, r.Nfo.Aya, 40+i)
+			}
+		}
+	}
+}
+
+func TestSearchSpecialCases(t *testing.T) {
+	qs := newTestSearch(t)
+	tests := []struct {
+		query string
+		surah int
+	}{
+		{"ص", 38}, {"ق", 50}, {"ن", 68}, {"طه", 20}, {"طس", 27}, {"يس", 36},
+	}
+	for _, tt := range tests {
+		results := qs.Search(tt.query, -1)
+		if len(results) != 1 || results[0].Nfo.Surah != tt.surah || results[0].Nfo.Aya != 1 {
+			t.Errorf("%s found %d ayat, want %d:1", tt.query, len(results), tt.surah)
+			continue
+		}
+		r := results[0]
+		aya := r.StrBld.String()
+		if got := aya[r.Indexes[0] : r.Indexes[0]+r.Lens[0]]; got != tt.query {
+			t.Errorf("%s highlighted %q in %d:1", tt.query, got, tt.surah)
+		}
+	}
+	if results := qs.Search("صراط", -1); len(results) < 2 {
+		t.Errorf("صراط found %d ayat", len(results))
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/xml"
 	"strings"
 	"time"
-	"unicode/utf8"
 )
 
 // Structs and Models
@@ -19,31 +18,46 @@ type SearchMethod interface {
 	Search(text, pattern string, max int) []SearchMatch
 }
 
-type indexOfMethod struct{}
+type indexOfMethod struct {
+	Table *AyaTable
+}
 
 func (i indexOfMethod) Search(text, pattern string, max int) []SearchMatch {
 	start := time.Now()
-	var matches []SearchMatch
+	matches := make([]SearchMatch, 0)
+	if pattern == "" {
+		return matches
+	}
 	index := 0
-	for len(matches) < max {
-		println("check index = ", index)
-		newIndex := strings.Index(text[index:], pattern)
-		if newIndex == -1 {
+	for max < 0 || len(matches) < max {
+		found := strings.Index(text[index:], pattern)
+		if found == -1 {
 			break
 		}
-		println("index = ", newIndex)
-		matches = append(matches, *NewSearchMatch(text, newIndex, time.Since(start)))
-		index += newIndex + utf8.RuneCountInString(pattern)
-		println("new index = ", index)
+		index += found
+		match := newMatch(i.Table, text, index, time.Since(start))
+		match.Length = len(pattern)
+		matches = append(matches, *match)
+		index += len(pattern)
 	}
 	return matches
 }
 
 // RegexMethod implements the SearchMethod interface
-type RegexMethod struct{}
+type RegexMethod struct {
+	Table *AyaTable
+}
+
+// WildcardMethod implements the SearchMethod interface for queries with
+// letter wildcards, see CompileWildcard
+type WildcardMethod struct {
+	Table *AyaTable
+}
 
 // BruteForceMethod implements the SearchMethod interface
-type BruteForceMethod struct{}
+type BruteForceMethod struct {
+	Table *AyaTable
+}
 
 // BoyerMooreMethod implements the Boyer-Moore string search algorithm
 type BoyerMooreMethod struct {
@@ -51,26 +65,251 @@ type BoyerMooreMethod struct {
 	BadCharacter  []int
 	GoodSuffix    []int
 	PatternLength int
+	Table         *AyaTable
+}
+
+// AhoCorasickMethod implements the SearchMethod interface for many patterns
+// at once
+type AhoCorasickMethod struct {
+	Patterns []string
+	Table    *AyaTable
+	source   []string
+	nodes    []acNode
+}
+
+// FuzzyMethod implements the SearchMethod interface for matches within
+// MaxDistance edits of the pattern, weighting substitutions with Cost
+// (ArabicSubstitutionCost when nil)
+type FuzzyMethod struct {
+	MaxDistance float64
+	Cost        func(a, b rune) float64
+	Table       *AyaTable
+}
+
+// VocalizedMethod implements the SearchMethod interface over a vocalized
+// text, with the diacritics of the pattern binding
+type VocalizedMethod struct {
+	Table *AyaTable
 }
 
 type SearchMatch struct {
-	Index int
-	Begin int
-	End   int
-	Word  int
+	Index        int
+	Begin        int
+	End          int
+	Word         int
+	Surah        int
+	Aya          int
+	Length       int     // bytes matched at Index, 0 when it is the pattern length
+	Pattern      string  // pattern that produced the match, for multi-pattern methods
+	Distance     float64 // edit distance of the match, for approximate methods
+	WordDistance int     // words between the two terms of a NEAR match
+	Time         time.Duration
+}
+
+// AyaOffset byte layout of one "surah|aya|text" line of the quran text
+type AyaOffset struct {
+	Surah int
+	Aya   int
+	Line  int   // start of the line, before the surah and aya numbers
+	Begin int   // start of the aya text
+	End   int   // position of the trailing newline
+	Words []int // start of every word of the aya text
+}
+
+// Posting one occurrence of an indexed word
+type Posting struct {
+	Surah  int
+	Aya    int
+	Pos    int // position of the word in the aya text
+	Offset int // byte offset of the word in the quran text
+}
+
+// WordIndex inverted index from each normalized word to its postings
+type WordIndex struct {
+	Postings map[string][]Posting
+	Words    []string // sorted vocabulary, for prefix lookups
+}
+
+// WordIndexMethod implements the SearchMethod interface over a WordIndex
+type WordIndexMethod struct {
+	Index *WordIndex
+	Table *AyaTable
+}
+
+// SuffixArray sorted suffixes of a text with their longest common prefixes
+type SuffixArray struct {
+	Text string
+	SA   []int32
+	LCP  []int32
+}
+
+// SuffixArrayMethod implements the SearchMethod interface over a SuffixArray
+type SuffixArrayMethod struct {
+	Index *SuffixArray
+	Table *AyaTable
+}
+
+// Repeat substring occurring more than once in the text
+type Repeat struct {
+	Text  string
+	Count int
+}
+
+// Normalizer orthographic folding rules, each one can be turned off
+type Normalizer struct {
+	Alef        bool // أ إ آ to ا
+	Wasla       bool // ٱ to ا
+	TaMarbuta   bool // ة to ه
+	AlefMaqsura bool // ى to ي
+	Hamza       bool // ؤ ئ to ء
+	Tatweel     bool // drop ـ
+	Diacritics  bool // drop the harakat and marks of quranicMarks
+	AlefMadda   bool // آ to ا
+	Dagger      bool // ٰ to ا where the simple script writes the alef
+	Rasm        bool // letters to their dotless skeleton, drop ء
+}
+
+// Shadow normalized copy of the quran text, searched in place of it
+type Shadow struct {
+	Quran    string
+	Offsets  []int // offset in the quran text of every byte of Quran
+	Ayat     *AyaTable
+	Words    *WordIndex
+	Suffixes *SuffixArray
+}
+
+// WordKey numbers of a word, counted from 1 like the ayat
+type WordKey struct {
 	Surah int
 	Aya   int
-	Time  time.Duration
+	Word  int
+}
+
+// Morpheme one segment of a word of the morphology file
+type Morpheme struct {
+	Surah    int
+	Aya      int
+	Word     int
+	Segment  int
+	Form     string
+	POS      string
+	Root     string
+	Lemma    string
+	Features []string
+}
+
+// WordSpan words of the text a word of the morphology stands for: the
+// corpus counts the يا of a vocative as part of the word that follows it
+type WordSpan struct {
+	Pos   int // position of the first word in AyaOffset.Words
+	Count int
+}
+
+// Morphology word by word morphology of the quran
+type Morphology struct {
+	Morphemes []Morpheme
+	Segments  map[WordKey][]int    // positions in Morphemes of each word
+	Roots     map[string][]WordKey // words of each root, by rootKey, in text order
+	Spans     map[WordKey]WordSpan // words of the text of each word, see Align
+}
+
+// RootMethod implements the SearchMethod interface for the words of a root
+type RootMethod struct {
+	Morphology *Morphology
+	Table      *AyaTable
+}
+
+// MorphQuery constraints on a word segment, by field: root, lemma, pos,
+// person, gender, number, case and mood
+type MorphQuery map[string]string
+
+// MorphologyMethod implements the SearchMethod interface for the words
+// matching a MorphQuery
+type MorphologyMethod struct {
+	Morphology *Morphology
+	Table      *AyaTable
+}
+
+// QueryNode node of a parsed boolean query: QueryTerm, QueryAnd, QueryOr,
+// QueryNot or QueryNear
+type QueryNode interface {
+	String() string
+}
+
+// QueryTerm a word or a quoted phrase of a query, Pos its offset in runes
+type QueryTerm struct {
+	Text   string
+	Phrase bool
+	Pos    int
+}
+
+// QueryAnd the ayat matching both sides
+type QueryAnd struct {
+	Left, Right QueryNode
+}
+
+// QueryOr the ayat matching either side
+type QueryOr struct {
+	Left, Right QueryNode
+}
+
+// QueryNot the ayat not matching the node
+type QueryNot struct {
+	Node QueryNode
+}
+
+// QueryNear the two terms within Distance words of each other, in the same
+// aya or, with CrossAya, in two following ayat of a surah
+type QueryNear struct {
+	Left, Right *QueryTerm
+	Distance    int
+	CrossAya    bool
+}
+
+// QuerySyntaxError error of ParseQuery, Pos is the offset in runes of the
+// faulty part of the query
+type QuerySyntaxError struct {
+	Pos int
+	Msg string
+}
+
+// StemMethod implements the SearchMethod interface matching words by stem
+type StemMethod struct {
+	Index *WordIndex
+	Table *AyaTable
+}
+
+// WaznMethod implements the SearchMethod interface matching words on a
+// morphological pattern such as فاعل
+type WaznMethod struct {
+	Vocalized bool // the text is vocalized, a shadda of the pattern binds
+	Table     *AyaTable
+}
+
+// QuranIndex the tables of a quran text stored in an index file
+type QuranIndex struct {
+	Ayat     *AyaTable
+	Words    *WordIndex
+	Suffixes *SuffixArray
+}
+
+// AyaTable ordered offsets of every aya, used to resolve a match index
+// without rescanning the text
+type AyaTable struct {
+	Ayat []AyaOffset
 }
 
 type AyaMatch struct {
-	StrBld    strings.Builder
-	Nfo       SearchMatch
-	Len       int
-	MLen      int
-	SLen      int
-	Indexes   []int
-	PreSpaces int
+	StrBld     strings.Builder
+	Nfo        SearchMatch
+	Len        int
+	MLen       int
+	SLen       int
+	Indexes    []int
+	Lens       []int // length of each of Indexes
+	PreSpaces  int
+	Score      int    // number of query spellings found in the aya
+	DidYouMean string // remapped query, when the query was typed on the wrong layout
 }
 
 type Quran struct {
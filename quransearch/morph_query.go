@@ -0,0 +1,157 @@
+package quransearch
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrNoMorphology a morphology query was made before LoadMorphology
+var ErrNoMorphology = errors.New("morphology not loaded")
+
+// morphFields the fields a MorphQuery can constrain
+var morphFields = []string{"root", "lemma", "pos", "person", "gender", "number", "case", "mood"}
+
+// pgnTag person, gender and number tag, such as 3MS, MP or 2D
+var pgnTag = regexp.MustCompile(`^([123])?([MF])?([SDP])?$`)
+
+// ParseMorphQuery Parse a query such as "lemma:قال AND pos:V" or
+// "pos:N case:ACC root:ع ل م" into its constraints; words following a field
+// without a colon continue its value
+func ParseMorphQuery(q string) (MorphQuery, error) {
+	mq := make(MorphQuery)
+	field := ""
+	for _, token := range strings.Fields(q) {
+		if token == "AND" {
+			field = ""
+			continue
+		}
+		name, value, ok := strings.Cut(token, ":")
+		if !ok {
+			if field == "" {
+				return nil, fmt.Errorf("ParseMorphQuery: %q is not a field:value", token)
+			}
+			mq[field] = mq[field] + morphSeparator(field) + token
+			continue
+		}
+
+		field = strings.ToLower(name)
+		if !isMorphField(field) {
+			return nil, fmt.Errorf("ParseMorphQuery: unknown field %q", name)
+		}
+		if field != "root" && field != "lemma" {
+			value = strings.ToUpper(value)
+		}
+		mq[field] = value
+	}
+	if len(mq) == 0 {
+		return nil, fmt.Errorf("ParseMorphQuery: empty query")
+	}
+	return mq, nil
+}
+
+// morphSeparator Root letters are joined, other values keep their spaces
+func morphSeparator(field string) string {
+	if field == "root" {
+		return ""
+	}
+	return " "
+}
+
+func isMorphField(field string) bool {
+	for _, f := range morphFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMorphQuery Check whether q starts with a morphology field
+func IsMorphQuery(q string) bool {
+	name, _, ok := strings.Cut(q, ":")
+	return ok && isMorphField(strings.ToLower(name))
+}
+
+// Feature Return the value of a field for the segment, "" when it has none
+func (m *Morpheme) Feature(field string) string {
+	switch field {
+	case "root":
+		return m.Root
+	case "lemma":
+		return m.Lemma
+	case "pos":
+		return m.POS
+	}
+
+	for _, tag := range m.Features {
+		tag = strings.TrimPrefix(tag, "MOOD:")
+		switch field {
+		case "case":
+			if tag == "NOM" || tag == "ACC" || tag == "GEN" {
+				return tag
+			}
+		case "mood":
+			if tag == "IND" || tag == "SUBJ" || tag == "JUS" {
+				return tag
+			}
+		default:
+			pgn := pgnTag.FindStringSubmatch(tag)
+			if tag == "" || pgn == nil {
+				continue
+			}
+			part := map[string]string{"person": pgn[1], "gender": pgn[2], "number": pgn[3]}[field]
+			if part != "" {
+				return part
+			}
+		}
+	}
+	return ""
+}
+
+// Match Check the segment against every constraint of the query
+func (m *Morpheme) Match(mq MorphQuery) bool {
+	for field, value := range mq {
+		switch field {
+		case "root":
+			if rootKey(m.Root) != rootKey(value) {
+				return false
+			}
+		case "lemma":
+			// the lemmas of the corpus are vocalized
+			if normalizeWord(m.Lemma) != normalizeWord(value) {
+				return false
+			}
+		default:
+			if m.Feature(field) != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Find Return the words with a segment matching the query, in text order
+func (mo *Morphology) Find(mq MorphQuery) []WordKey {
+	var words []WordKey
+	for i := range mo.Morphemes {
+		m := &mo.Morphemes[i]
+		if !m.Match(mq) {
+			continue
+		}
+		if key := m.Key(); len(words) == 0 || words[len(words)-1] != key {
+			words = append(words, key)
+		}
+	}
+	return words
+}
+
+// Search finds every word matching the morphology query of the pattern
+func (mm *MorphologyMethod) Search(text, pattern string, max int) []SearchMatch {
+	mq, err := ParseMorphQuery(pattern)
+	if err != nil || mm.Morphology == nil {
+		return make([]SearchMatch, 0)
+	}
+	return mm.Morphology.wordMatches(text, mm.Table, mm.Morphology.Find(mq), max)
+}
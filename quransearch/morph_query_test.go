@@ -0,0 +1,103 @@
+package quransearch
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseMorphQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  MorphQuery
+	}{
+		{"lemma:قال AND pos:V", MorphQuery{"lemma": "قال", "pos": "V"}},
+		{"pos:n case:acc root:ع ل م", MorphQuery{"pos": "N", "case": "ACC", "root": "علم"}},
+		{"Person:1 number:p", MorphQuery{"person": "1", "number": "P"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseMorphQuery(tt.query)
+		if err != nil {
+			t.Errorf("ParseMorphQuery(%q): %v", tt.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseMorphQuery(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	for _, q := range []string{"", "قال", "tense:PERF", "AND"} {
+		if _, err := ParseMorphQuery(q); err == nil {
+			t.Errorf("ParseMorphQuery(%q) parsed", q)
+		}
+	}
+}
+
+func TestSearchMorphology(t *testing.T) {
+	tests := []struct {
+		query string
+		ayat  []string
+	}{
+		{"pos:V", []string{"1:5", "1:6", "1:7", "2:21"}},
+		{"pos:V person:1 mood:IND", []string{"1:5"}},
+		{"lemma:الله", []string{"1:1", "1:2"}},
+		{"pos:ADJ case:ACC", []string{"1:6"}},
+		{"pos:N case:NOM", []string{"1:2", "2:21"}},
+	}
+	for _, file := range morphologySamples {
+		qs := newTestSearch(t)
+		if err := qs.LoadMorphology(file); err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			results, err := qs.SearchMorphology(tt.query, -1)
+			if err != nil {
+				t.Errorf("%s: %q: %v", file, tt.query, err)
+				continue
+			}
+			var ayat []string
+			for _, r := range results {
+				ayat = append(ayat, fmt.Sprintf("%d:%d", r.Nfo.Surah, r.Nfo.Aya))
+			}
+			if !reflect.DeepEqual(ayat, tt.ayat) {
+				t.Errorf("%s: %q found ayat %v, want %v", file, tt.query, ayat, tt.ayat)
+			}
+		}
+	}
+}
+
+func TestSearchMorphologyHighlight(t *testing.T) {
+	qs := newTestSearch(t)
+	if _, err := qs.SearchMorphology("pos:V", -1); !errors.Is(err, ErrNoMorphology) {
+		t.Errorf("a query without a morphology gave %v, want ErrNoMorphology", err)
+	}
+
+	// the corpus counts يا أيها as one word, the text as two
+	tests := []struct {
+		query string
+		word  string // highlighted in 2:21
+	}{
+		{"lemma:أي", "يا أيها"},
+		{"pos:N root:نوس", "الناس"},
+		{"pos:V root:خلق", "خلقكم"},
+		{"pos:V root:وقي", "تتقون"},
+	}
+	for _, file := range morphologySamples {
+		if err := qs.LoadMorphology(file); err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			results, err := qs.SearchMorphology(tt.query, -1)
+			if err != nil || len(results) != 1 {
+				t.Errorf("%s: %q found %d ayat, %v", file, tt.query, len(results), err)
+				continue
+			}
+			r := results[0]
+			aya := r.StrBld.String()
+			if got := aya[r.Indexes[0] : r.Indexes[0]+r.Lens[0]]; got != tt.word {
+				t.Errorf("%s: %q highlighted %q, want %q", file, tt.query, got, tt.word)
+			}
+		}
+	}
+}
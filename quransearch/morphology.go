@@ -0,0 +1,429 @@
+package quransearch
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ROOT_PREFIX  = "root:"
+	basmala      = "بسم الله الرحمن الرحيم "
+	basmalaWords = 4
+)
+
+// ParseMorphology Load a word by word morphology file.
+//
+// The file is tab separated, one segment per line, in either of two
+// layouts. The morphology of the Quranic Arabic Corpus, the file
+// quranic-corpus-morphology-0.4.txt of https://corpus.quran.com/download/,
+// has four fields with the form, lemma and root in Buckwalter:
+//
+//	(surah:aya:word:segment)	FORM	TAG	FEATURES
+//	(1:1:1:2)	somi	N	STEM|POS:N|LEM:{som|ROOT:smw|M|GEN
+//
+// The layout of data/morphology-sample.tsv has six, in Arabic letters:
+//
+//	surah:aya:word:segment	form	pos	root	lemma	features
+//
+// with the location optionally in parentheses, the root letters optionally
+// separated by spaces, and the features separated by "|". Empty lines, the
+// LOCATION header and lines starting with "#" are skipped.
+func ParseMorphology(filename string, morphology *Morphology) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("ParseMorphology: %v", err)
+	}
+	defer func(file *os.File) {
+		err = file.Close()
+		if err != nil {
+			log.Fatal("ParseMorphology: Could not close file!")
+		}
+	}(file)
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "LOCATION\t") {
+			continue
+		}
+		m, err := parseMorpheme(text)
+		if err != nil {
+			return fmt.Errorf("ParseMorphology: %s:%d: %v", filename, line, err)
+		}
+		morphology.Morphemes = append(morphology.Morphemes, m)
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("ParseMorphology: %v", err)
+	}
+
+	morphology.index()
+	return nil
+}
+
+func parseMorpheme(text string) (Morpheme, error) {
+	var m Morpheme
+	fields := strings.Split(text, "\t")
+	if len(fields) < 3 {
+		return m, fmt.Errorf("want at least 3 fields, got %d", len(fields))
+	}
+	for len(fields) < 6 {
+		fields = append(fields, "")
+	}
+
+	location := strings.Trim(fields[0], "()")
+	parts := strings.Split(location, ":")
+	if len(parts) != 4 {
+		return m, fmt.Errorf("bad location %q", fields[0])
+	}
+	numbers := make([]int, 4)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 1 {
+			return m, fmt.Errorf("bad location %q", fields[0])
+		}
+		numbers[i] = n
+	}
+	m.Surah, m.Aya, m.Word, m.Segment = numbers[0], numbers[1], numbers[2], numbers[3]
+
+	if isCorpusFeatures(fields) {
+		parseCorpusFeatures(&m, fields)
+		return m, nil
+	}
+	m.Form = fields[1]
+	m.POS = fields[2]
+	m.Root = strings.ReplaceAll(fields[3], " ", "")
+	m.Lemma = fields[4]
+	if fields[5] != "" {
+		m.Features = strings.Split(fields[5], "|")
+	}
+	return m, nil
+}
+
+// isCorpusFeatures Check whether the fields are those of the Quranic Arabic
+// Corpus, the features starting with the kind of segment
+func isCorpusFeatures(fields []string) bool {
+	if fields[4] != "" || fields[5] != "" {
+		return false
+	}
+	kind, _, _ := strings.Cut(fields[3], "|")
+	return kind == "PREFIX" || kind == "STEM" || kind == "SUFFIX"
+}
+
+// parseCorpusFeatures Fill the segment from the Buckwalter form and the
+// features of the Quranic Arabic Corpus: the POS, LEM and ROOT features
+// become fields and the kind of segment the PREF or SUFF feature
+func parseCorpusFeatures(m *Morpheme, fields []string) {
+	m.Form = FromBuckwalter(fields[1])
+	m.POS = fields[2]
+	for _, feature := range strings.Split(fields[3], "|") {
+		name, value, _ := strings.Cut(feature, ":")
+		switch {
+		case feature == "STEM" || name == "POS":
+		case feature == "PREFIX":
+			m.Features = append(m.Features, "PREF")
+		case feature == "SUFFIX":
+			m.Features = append(m.Features, "SUFF")
+		case name == "LEM":
+			m.Lemma = FromBuckwalter(value)
+		case name == "ROOT":
+			m.Root = FromBuckwalter(value)
+		case name == "PRON":
+			// the person, gender and number of an attached pronoun
+			m.Features = append(m.Features, value)
+		default:
+			m.Features = append(m.Features, feature)
+		}
+	}
+}
+
+// rootKey Return the root with its hamza written ء whatever its seat, the
+// corpus writing the أ of أمن and أله as an alef
+func rootKey(root string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case 'أ', 'إ', 'آ', 'ا', 'ؤ', 'ئ':
+			return 'ء'
+		case ' ':
+			return -1
+		}
+		return r
+	}, root)
+}
+
+// index Group the segments by word and the words by root
+func (mo *Morphology) index() {
+	sort.SliceStable(mo.Morphemes, func(i, j int) bool {
+		return mo.Morphemes[i].Key().less(mo.Morphemes[j].Key())
+	})
+
+	mo.Segments = make(map[WordKey][]int)
+	mo.Roots = make(map[string][]WordKey)
+	for i, m := range mo.Morphemes {
+		key := m.Key()
+		mo.Segments[key] = append(mo.Segments[key], i)
+		if root := rootKey(m.Root); root != "" {
+			words := mo.Roots[root]
+			if len(words) == 0 || words[len(words)-1] != key {
+				mo.Roots[root] = append(words, key)
+			}
+		}
+	}
+}
+
+// Key Return the key of the word the segment belongs to
+func (m *Morpheme) Key() WordKey {
+	return WordKey{Surah: m.Surah, Aya: m.Aya, Word: m.Word}
+}
+
+func (k WordKey) less(o WordKey) bool {
+	if k.Surah != o.Surah {
+		return k.Surah < o.Surah
+	}
+	if k.Aya != o.Aya {
+		return k.Aya < o.Aya
+	}
+	return k.Word < o.Word
+}
+
+// Search finds every word whose root is the pattern, spaces and the
+// "root:" prefix ignored
+func (rm *RootMethod) Search(text, pattern string, max int) []SearchMatch {
+	root := rootKey(strings.TrimPrefix(pattern, ROOT_PREFIX))
+	if rm.Morphology == nil {
+		return make([]SearchMatch, 0)
+	}
+	return rm.Morphology.wordMatches(text, rm.Table, rm.Morphology.Roots[root], max)
+}
+
+// skeletonNormalizer the normalizer of the letters both scripts share
+var skeletonNormalizer = NewNormalizer()
+
+// skeleton Return the letters of a word the Uthmani and the simple scripts
+// both write: the word normalized, its alefs and hamzas left out
+func skeleton(word string) []rune {
+	var s []rune
+	for _, r := range skeletonNormalizer.Normalize(word) {
+		if r != 'ا' && r != 'ء' {
+			s = append(s, r)
+		}
+	}
+	return s
+}
+
+// editDistance Return the Levenshtein distance between a and b
+func editDistance(a, b []rune) int {
+	var buf [32]int
+	row := buf[:0]
+	if len(b) >= len(buf) {
+		row = make([]int, 0, len(b)+1)
+	}
+	row = row[:len(b)+1]
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		diag := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			diag, row[j] = row[j], minInt(minInt(row[j]+1, row[j-1]+1), diag+cost)
+		}
+	}
+	return row[len(b)]
+}
+
+// maxSpan the most words of the text a word of the morphology stands for,
+// three in يا ابن أم
+const maxSpan = 3
+
+// Align Map the words of the morphology onto the words of the text, aya by
+// aya. The corpus counts يا أيها, ها أنتم or يا ابن أم as one word where the
+// text splits them, so the words are aligned on their skeletons, each word
+// of the morphology taking one to three consecutive words of the text; the
+// pause marks the text writes as words are skipped
+func (mo *Morphology) Align(quran string, table *AyaTable) {
+	mo.Spans = make(map[WordKey]WordSpan)
+	for i := 0; i < len(mo.Morphemes); {
+		first := mo.Morphemes[i].Key()
+		var words []WordKey
+		for ; i < len(mo.Morphemes); i++ {
+			key := mo.Morphemes[i].Key()
+			if key.Surah != first.Surah || key.Aya != first.Aya {
+				break
+			}
+			if len(words) == 0 || words[len(words)-1] != key {
+				words = append(words, key)
+			}
+		}
+
+		n, ok := table.Lookup(first.Surah, first.Aya)
+		if !ok {
+			continue
+		}
+		ao := &table.Ayat[n]
+		mo.alignAya(quran, ao, ao.basmalaWords(quran), words)
+	}
+}
+
+// alignAya Align the words of the morphology of an aya with the words of
+// the text from begin on, at the least cost: the distance between the
+// skeletons relative to their length, a quarter for each word added to a
+// span, one for each word left out on either side
+func (mo *Morphology) alignAya(quran string, ao *AyaOffset, begin int, words []WordKey) {
+	type step struct {
+		cost  float64
+		words int // words of the morphology taken, 0 or 1
+		text  int // words of the text taken
+	}
+
+	forms := make([][]rune, len(words))
+	for w, key := range words {
+		var b strings.Builder
+		for _, i := range mo.Segments[key] {
+			b.WriteString(mo.Morphemes[i].Form)
+		}
+		forms[w] = skeleton(b.String())
+	}
+	text := make([][]rune, len(ao.Words)-begin)
+	for t := range text {
+		text[t] = skeleton(ao.word(quran, begin+t))
+	}
+
+	// most ayat count the same words once the pause marks are left out
+	var letters []int
+	for t := range text {
+		if len(text[t]) > 0 {
+			letters = append(letters, t)
+		}
+	}
+	if alike(forms, text, letters) {
+		for w, key := range words {
+			mo.Spans[key] = WordSpan{Pos: begin + letters[w], Count: 1}
+		}
+		return
+	}
+
+	m, n := len(forms), len(text)
+	cells := make([]step, (m+1)*(n+1))
+	steps := make([][]step, m+1)
+	for w := range steps {
+		steps[w] = cells[w*(n+1) : (w+1)*(n+1)]
+	}
+	for i := range cells {
+		cells[i].cost = math.Inf(1)
+	}
+	steps[0][0].cost = 0
+	relax := func(w, t, dw, dt int, cost float64) {
+		if c := steps[w][t].cost + cost; c < steps[w+dw][t+dt].cost {
+			steps[w+dw][t+dt] = step{cost: c, words: dw, text: dt}
+		}
+	}
+
+	// the text takes n-m more words than the morphology over the whole aya,
+	// so a word is never far from the diagonal shifted by up to n-m
+	for w := 0; w <= m; w++ {
+		lo := maxInt(w+minInt(n-m, 0)-maxSpan, 0)
+		hi := minInt(w+maxInt(n-m, 0)+maxSpan, n)
+		for t := lo; t <= hi; t++ {
+			if math.IsInf(steps[w][t].cost, 1) {
+				continue
+			}
+			if t < n {
+				skip := 1.0
+				if len(text[t]) == 0 {
+					skip = 0
+				}
+				relax(w, t, 0, 1, skip)
+			}
+			if w == m {
+				continue
+			}
+			relax(w, t, 1, 0, 1)
+			joined := make([]rune, 0, 32)
+			for k := 1; k <= maxSpan && t+k <= n; k++ {
+				joined = append(joined, text[t+k-1]...)
+				longest := maxInt(maxInt(len(forms[w]), len(joined)), 1)
+				cost := float64(editDistance(forms[w], joined))/float64(longest) + 0.25*float64(k-1)
+				relax(w, t, 1, k, cost)
+			}
+		}
+	}
+
+	for w, t := m, n; w > 0 || t > 0; {
+		s := steps[w][t]
+		w, t = w-s.words, t-s.text
+		if s.words == 1 && s.text > 0 {
+			mo.Spans[words[w]] = WordSpan{Pos: begin + t, Count: s.text}
+		}
+	}
+}
+
+// alike Check whether the words of a are pairwise alike with the words of
+// b at the positions, fewer than half of the letters of each pair differing
+func alike(a, b [][]rune, positions []int) bool {
+	if len(a) != len(positions) {
+		return false
+	}
+	for i, p := range positions {
+		if d := editDistance(a[i], b[p]); d > 0 && 2*d >= maxInt(len(a[i]), len(b[p])) {
+			return false
+		}
+	}
+	return true
+}
+
+// wordMatches Resolve the morphology words into matches on the text, each
+// covering the words of the text it stands for
+func (mo *Morphology) wordMatches(text string, table *AyaTable, words []WordKey, max int) []SearchMatch {
+	start := time.Now()
+	matches := make([]SearchMatch, 0)
+	if table == nil {
+		table = NewAyaTable(text)
+	}
+	if mo.Spans == nil {
+		mo.Align(text, table)
+	}
+
+	for _, key := range words {
+		if max >= 0 && len(matches) >= max {
+			break
+		}
+		span, ok := mo.Spans[key]
+		if !ok {
+			continue
+		}
+		n, ok := table.Lookup(key.Surah, key.Aya)
+		if !ok {
+			continue
+		}
+		ao := &table.Ayat[n]
+		last := span.Pos + span.Count - 1
+		if last >= len(ao.Words) {
+			continue
+		}
+		match := table.NewSearchMatch(ao.Words[span.Pos], time.Since(start))
+		match.Length = ao.wordEnd(last) - ao.Words[span.Pos]
+		matches = append(matches, *match)
+	}
+
+	return matches
+}
+
+// basmalaWords Return the number of words of the basmala that quran.txt
+// puts before the first aya of a surah, which the morphology does not count
+func (ao *AyaOffset) basmalaWords(quran string) int {
+	if ao.Aya == 1 && ao.Surah != 1 && strings.HasPrefix(quran[ao.Begin:ao.End], basmala) {
+		return basmalaWords
+	}
+	return 0
+}
@@ -0,0 +1,179 @@
+package quransearch
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+// morphologySamples the two layouts ParseMorphology reads, both holding the
+// morphology of al-Fatiha
+var morphologySamples = []string{
+	"../data/morphology-sample.tsv",
+	"../data/morphology-corpus-sample.txt",
+}
+
+func TestParseMorphologyCorpus(t *testing.T) {
+	var mo Morphology
+	if err := ParseMorphology(morphologySamples[1], &mo); err != nil {
+		t.Fatal(err)
+	}
+	m := mo.Morphemes[mo.Segments[WordKey{Surah: 1, Aya: 1, Word: 1}][1]]
+	want := Morpheme{
+		Surah: 1, Aya: 1, Word: 1, Segment: 2,
+		Form: "سْمِ", POS: "N", Root: "سمو", Lemma: "ٱسْم",
+		Features: []string{"M", "GEN"},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("got %+v, want %+v", m, want)
+	}
+
+	suffix := mo.Morphemes[mo.Segments[WordKey{Surah: 1, Aya: 5, Word: 1}][1]]
+	if !reflect.DeepEqual(suffix.Features, []string{"SUFF", "2MS"}) {
+		t.Errorf("the pronoun of إياك is %v", suffix.Features)
+	}
+}
+
+func TestSearchRootSamples(t *testing.T) {
+	tests := []struct {
+		root string
+		ayat int
+	}{
+		{"root:ر ح م", 2},
+		{"root:أ ل ه", 2},
+		{"root:ءله", 2},
+		{"root:ص ر ط", 2},
+		{"root:ك ت ب", 0},
+	}
+	for _, file := range morphologySamples {
+		qs := newTestSearch(t)
+		if err := qs.LoadMorphology(file); err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			if got := qs.Search(tt.root, -1); len(got) != tt.ayat {
+				t.Errorf("%s: %s found %d ayat, want %d", file, tt.root, len(got), tt.ayat)
+			}
+		}
+	}
+}
+
+// corpusPath the full morphology of the Quranic Arabic Corpus, downloaded
+// from https://corpus.quran.com/download/ and not shipped with the data
+const corpusPath = "../data/quranic-corpus-morphology-0.4.txt"
+
+// madinaMorphology Return a morphology holding one segment per word of
+// madina.xml, which counts its words as the corpus does, pause marks left out
+func madinaMorphology(t *testing.T) *Morphology {
+	t.Helper()
+	xml := newTestXMLSearch(t)
+	mo := &Morphology{}
+	for _, ao := range xml.Ayat.Ayat {
+		word := 0
+		for pos := range ao.Words {
+			form := ao.word(xml.Quran, pos)
+			if len(skeleton(form)) == 0 {
+				continue
+			}
+			word++
+			mo.Morphemes = append(mo.Morphemes, Morpheme{Surah: ao.Surah, Aya: ao.Aya, Word: word, Segment: 1, Form: form})
+		}
+	}
+	mo.index()
+	return mo
+}
+
+func TestAlign(t *testing.T) {
+	qs := newTestSearch(t)
+	mo := madinaMorphology(t)
+	mo.Align(qs.Quran, qs.Ayat)
+
+	tests := []struct {
+		key  WordKey
+		want string
+	}{
+		{WordKey{Surah: 1, Aya: 1, Word: 1}, "بسم"},
+		{WordKey{Surah: 2, Aya: 1, Word: 1}, "الم"},
+		{WordKey{Surah: 2, Aya: 21, Word: 1}, "يا أيها"},
+		{WordKey{Surah: 2, Aya: 21, Word: 4}, "ربكم"},
+		{WordKey{Surah: 2, Aya: 33, Word: 3}, "أنبئهم"},
+		{WordKey{Surah: 3, Aya: 66, Word: 1}, "ها أنتم"},
+		{WordKey{Surah: 20, Aya: 94, Word: 2}, "يا ابن أم"},
+		{WordKey{Surah: 114, Aya: 6, Word: 3}, "والناس"},
+	}
+	for _, tt := range tests {
+		span, ok := mo.Spans[tt.key]
+		if !ok {
+			t.Errorf("%v is not aligned", tt.key)
+			continue
+		}
+		n, _ := qs.Ayat.Lookup(tt.key.Surah, tt.key.Aya)
+		ao := &qs.Ayat.Ayat[n]
+		if got := qs.Quran[ao.Words[span.Pos]:ao.wordEnd(span.Pos+span.Count-1)]; got != tt.want {
+			t.Errorf("%v is aligned with %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	// every word is aligned, in order, without two sharing a word of the text
+	var last WordKey
+	end := 0
+	for _, m := range mo.Morphemes {
+		key := m.Key()
+		span, ok := mo.Spans[key]
+		if !ok {
+			t.Fatalf("%v %s is not aligned", key, m.Form)
+		}
+		if key.Surah != last.Surah || key.Aya != last.Aya {
+			end = 0
+		}
+		if span.Pos < end || span.Count < 1 {
+			t.Fatalf("%v is aligned with %+v, overlapping the word before", key, span)
+		}
+		last, end = key, span.Pos+span.Count
+	}
+}
+
+func TestSearchRootAligned(t *testing.T) {
+	for _, file := range morphologySamples {
+		qs := newTestSearch(t)
+		if err := qs.LoadMorphology(file); err != nil {
+			t.Fatal(err)
+		}
+		results := qs.SearchRoot("ربب", -1)
+		if len(results) != 2 {
+			t.Fatalf("%s: ربب found %d ayat, want 2", file, len(results))
+		}
+		r := results[1]
+		aya := r.StrBld.String()
+		if got := aya[r.Indexes[0] : r.Indexes[0]+r.Lens[0]]; r.Nfo.Aya != 21 || got != "ربكم" {
+			t.Errorf("%s: ربب highlighted %q in %d:%d, want ربكم in 2:21", file, got, r.Nfo.Surah, r.Nfo.Aya)
+		}
+	}
+}
+
+// TestCorpus runs against the full corpus when it has been downloaded to
+// data/quranic-corpus-morphology-0.4.txt
+func TestCorpus(t *testing.T) {
+	if _, err := os.Stat(corpusPath); err != nil {
+		t.Skipf("%s not found", corpusPath)
+	}
+	qs := newTestSearch(t)
+	if err := qs.LoadMorphology(corpusPath); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(qs.Morphology.Spans); n != 77430 {
+		t.Errorf("%d words aligned, want the 77430 of the corpus", n)
+	}
+
+	for _, r := range qs.SearchRoot("ربب", -1) {
+		if r.Nfo.Surah != 2 || r.Nfo.Aya != 21 {
+			continue
+		}
+		aya := r.StrBld.String()
+		if got := aya[r.Indexes[0] : r.Indexes[0]+r.Lens[0]]; got != "ربكم" {
+			t.Errorf("ربب highlighted %q in 2:21", got)
+		}
+		return
+	}
+	t.Error("ربب did not find 2:21")
+}
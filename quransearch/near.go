@@ -0,0 +1,147 @@
+package quransearch
+
+import (
+	"sort"
+	"strings"
+)
+
+// nearHit two matches within the distance of a NEAR, first before second
+type nearHit struct {
+	first, second int // positions in the aya table
+	a, b          SearchMatch
+	distance      int
+}
+
+// wordSpan the words a match covers, numbered through the whole text
+type wordSpan struct {
+	match      SearchMatch
+	aya        int
+	start, end int
+}
+
+// SearchNear Search a within k words of b, in the same aya or, with
+// crossAya, in the next aya of the surah; one result per match of a with
+// its nearest b, both highlighted, the ayat of a hit across two ayat put
+// together and the distance in words in Nfo.WordDistance
+func (qs *QuranSearch) SearchNear(a, b string, k int, crossAya bool, max int) []AyaMatch {
+	near := &QueryNear{
+		Left:     &QueryTerm{Text: a, Phrase: strings.Contains(a, " ")},
+		Right:    &QueryTerm{Text: b, Phrase: strings.Contains(b, " ")},
+		Distance: k,
+		CrossAya: crossAya,
+	}
+	return qs.buildNearResults(qs.newQueryEval().near(near), max)
+}
+
+// wordSpans Locate the words of the matches, in text order
+func (qs *QuranSearch) wordSpans(matches []SearchMatch, before []int) []wordSpan {
+	spans := make([]wordSpan, 0, len(matches))
+	for _, m := range matches {
+		n := qs.Ayat.Find(m.Index)
+		if n < 0 {
+			continue
+		}
+		ao := &qs.Ayat.Ayat[n]
+		last := m.Index
+		if m.Length > 0 {
+			last += m.Length - 1
+		}
+		spans = append(spans, wordSpan{
+			match: m,
+			aya:   n,
+			start: before[n] + ao.WordAt(m.Index),
+			end:   before[n] + ao.WordAt(last),
+		})
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].match.Index < spans[j].match.Index
+	})
+	return spans
+}
+
+// near Return the nearest match of the right term to each match of the
+// left one, when within the distance
+func (ev *queryEval) near(n *QueryNear) []nearHit {
+	qs := ev.qs
+	before := make([]int, len(qs.Ayat.Ayat)+1)
+	for i := range qs.Ayat.Ayat {
+		before[i+1] = before[i] + len(qs.Ayat.Ayat[i].Words)
+	}
+	left := qs.wordSpans(ev.find(n.Left), before)
+	right := qs.wordSpans(ev.find(n.Right), before)
+
+	longest := 0
+	for _, s := range right {
+		longest = maxInt(longest, s.end-s.start)
+	}
+
+	var hits []nearHit
+	for _, a := range left {
+		from := sort.Search(len(right), func(i int) bool {
+			return right[i].start >= a.start-n.Distance-longest
+		})
+		best := nearHit{distance: -1}
+		for i := from; i < len(right) && right[i].start <= a.end+n.Distance; i++ {
+			b := right[i]
+			d := b.start - a.end
+			if a.start > b.end {
+				d = a.start - b.end
+			} else if b.start <= a.end {
+				continue // the terms overlap
+			}
+			if d > n.Distance || (best.distance >= 0 && d >= best.distance) ||
+				!qs.nearAyat(a.aya, b.aya, n.CrossAya) {
+				continue
+			}
+			first, second := a, b
+			if b.match.Index < a.match.Index {
+				first, second = b, a
+			}
+			best = nearHit{
+				first: first.aya, second: second.aya,
+				a: first.match, b: second.match,
+				distance: d,
+			}
+		}
+		if best.distance >= 0 {
+			hits = append(hits, best)
+		}
+	}
+	return hits
+}
+
+// nearAyat Check whether two ayat can hold a hit: the same aya, or with
+// cross the next one of the same surah
+func (qs *QuranSearch) nearAyat(a, b int, cross bool) bool {
+	if a == b {
+		return true
+	}
+	if !cross || (a-b != 1 && b-a != 1) {
+		return false
+	}
+	return qs.Ayat.Ayat[a].Surah == qs.Ayat.Ayat[b].Surah
+}
+
+// buildNearResults Build one result per hit, a hit across two ayat showing
+// both of them
+func (qs *QuranSearch) buildNearResults(hits []nearHit, max int) []AyaMatch {
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].a.Index < hits[j].a.Index
+	})
+	var results = make([]AyaMatch, 0)
+	for _, hit := range hits {
+		if max >= 0 && len(results) >= max {
+			break
+		}
+		am := NewAyaMatch(qs.Quran, qs.AyaBegin, hit.a, hit.a.Length)
+		if hit.first == hit.second {
+			am.AddSpan(hit.b.Index, hit.b.Length)
+		} else {
+			ao := &qs.Ayat.Ayat[hit.second]
+			am.AppendAya(qs.Quran, ao.Begin, ao.End, hit.b.Index, hit.b.Length)
+		}
+		am.Nfo.WordDistance = hit.distance
+		results = append(results, *am)
+	}
+	return results
+}
@@ -0,0 +1,83 @@
+package quransearch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchNear(t *testing.T) {
+	qs := newTestSearch(t)
+
+	// the terms in either order: the basmala, and الرحمن after it in 55:1
+	results := qs.SearchNear("الرحمن", "الرحيم", 1, false, -1)
+	want := strings.Count(qs.Quran, "الرحمن الرحيم") + strings.Count(qs.Quran, "الرحيم الرحمن")
+	if len(results) != want {
+		t.Errorf("got %d hits of الرحمن NEAR/1 الرحيم, want %d", len(results), want)
+	}
+
+	previous := 0
+	for _, k := range []int{1, 3, 10} {
+		results := qs.SearchNear("موسى", "فرعون", k, false, -1)
+		if len(results) < previous {
+			t.Errorf("NEAR/%d found %d hits, fewer than a smaller distance", k, len(results))
+		}
+		previous = len(results)
+		for _, r := range results {
+			if r.Nfo.WordDistance < 1 || r.Nfo.WordDistance > k {
+				t.Errorf("NEAR/%d hit at %d:%d is %d words apart", k, r.Nfo.Surah, r.Nfo.Aya, r.Nfo.WordDistance)
+			}
+			if len(r.Indexes) != 2 {
+				t.Errorf("NEAR/%d hit at %d:%d has %d highlights, want 2", k, r.Nfo.Surah, r.Nfo.Aya, len(r.Indexes))
+			}
+		}
+	}
+	if previous == 0 {
+		t.Error("no hit of موسى NEAR/10 فرعون")
+	}
+}
+
+func TestSearchNearCrossAya(t *testing.T) {
+	qs := newTestSearch(t)
+
+	// العالمين ends 1:2 and الرحمن starts 1:3
+	inFatiha := func(results []AyaMatch) bool {
+		for _, r := range results {
+			if r.Nfo.Surah == 1 && r.Nfo.Aya == 2 {
+				return true
+			}
+		}
+		return false
+	}
+	if inFatiha(qs.SearchNear("العالمين", "الرحمن", 1, false, -1)) {
+		t.Error("NEAR/1 crossed from 1:2 into 1:3")
+	}
+	results := qs.SearchNear("العالمين", "الرحمن", 1, true, -1)
+	if !inFatiha(results) {
+		t.Error("NEAR/1+ missed the hit from 1:2 into 1:3")
+	}
+	if r := results[0]; r.Nfo.WordDistance != 1 || !strings.Contains(r.StrBld.String(), "الرحيم") {
+		t.Errorf("NEAR/1+ hit is %d words apart, showing %q", r.Nfo.WordDistance, r.StrBld.String())
+	}
+}
+
+func TestSearchQueryNear(t *testing.T) {
+	qs := newTestSearch(t)
+	results, err := qs.SearchQuery("موسى NEAR/3 فرعون", -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := qs.SearchNear("موسى", "فرعون", 3, false, -1); len(results) != len(want) {
+		t.Errorf("SearchQuery found %d hits, SearchNear %d", len(results), len(want))
+	}
+
+	// inside a boolean query the distance is kept per aya
+	results, err = qs.SearchQuery("موسى NEAR/3 فرعون AND هارون", -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.Nfo.WordDistance < 1 || r.Nfo.WordDistance > 3 {
+			t.Errorf("hit at %d:%d is %d words apart", r.Nfo.Surah, r.Nfo.Aya, r.Nfo.WordDistance)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package quransearch
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	tatweel    = 'ـ'
+	daggerAlef = 'ٰ'
+)
+
+// quranicMarks the marks of uthmaniChars and the signs of the Uthmani text
+// it leaves out: the small high rounded zero, the small high lam alef, the
+// small low seen and meem and the empty centre stops
+const quranicMarks = uthmaniChars + "\u06df\u06d9\u06e3\u06ed\u06ea\u06eb"
+
+// NewNormalizer Constructor with every rule turned on
+func NewNormalizer() *Normalizer {
+	return &Normalizer{
+		Alef:        true,
+		Wasla:       true,
+		TaMarbuta:   true,
+		AlefMaqsura: true,
+		Hamza:       true,
+		Tatweel:     true,
+		Diacritics:  true,
+		AlefMadda:   true,
+		Dagger:      true,
+	}
+}
+
+// NewRasmNormalizer Constructor reducing the text to its rasm, the dotless
+// consonantal skeleton of the early copies
+func NewRasmNormalizer() *Normalizer {
+	return &Normalizer{
+		Alef:       true,
+		Wasla:      true,
+		Tatweel:    true,
+		Diacritics: true,
+		Rasm:       true,
+	}
+}
+
+// rasm the archigrapheme of each letter that shares its skeleton with
+// others; the hamza on the line is not part of the rasm and is dropped
+var rasm = map[rune]rune{
+	'ب': 'ٮ', 'ت': 'ٮ', 'ث': 'ٮ', 'ن': 'ٮ', 'ي': 'ٮ', 'ى': 'ٮ', 'ئ': 'ٮ',
+	'ج': 'ح', 'خ': 'ح',
+	'ذ': 'د', 'ز': 'ر', 'ش': 'س', 'ض': 'ص', 'ظ': 'ط', 'غ': 'ع',
+	'ف': 'ڡ', 'ق': 'ڡ',
+	'ة': 'ه', 'ؤ': 'و',
+	'ں': 'ٮ', 'ٯ': 'ڡ', // the dotless noon and qaf typed in a query
+	'ء': -1,
+}
+
+// fold Return the replacement of r, -1 to drop it
+func (n *Normalizer) fold(r rune) rune {
+	if a, ok := rasm[r]; ok && n.Rasm {
+		return a
+	}
+	switch {
+	case n.Alef && (r == 'أ' || r == 'إ' || r == 'آ'):
+		return 'ا'
+	case n.Wasla && r == 'ٱ':
+		return 'ا'
+	case n.AlefMadda && r == 'آ':
+		return 'ا'
+	case n.TaMarbuta && r == 'ة':
+		return 'ه'
+	case n.AlefMaqsura && r == 'ى':
+		return 'ي'
+	case n.Hamza && (r == 'ؤ' || r == 'ئ'):
+		return 'ء'
+	case r == tatweel:
+		if n.Tatweel {
+			return -1
+		}
+	case n.Diacritics && r != 'ٱ' && strings.ContainsRune(quranicMarks, r):
+		return -1
+	}
+	return r
+}
+
+// foldAt Return the replacement of the rune r at i in s: the dagger alef is
+// the alef of the simple script, unless dropped there as in ذلك or هذا, and
+// takes the place of the waw of الصلوٰة and of the ya of أدرىٰك
+func (n *Normalizer) foldAt(s string, i int, r rune) rune {
+	if !n.Dagger || n.Rasm {
+		return n.fold(r)
+	}
+	size := utf8.RuneLen(r)
+	switch {
+	case r == 'و' && strings.HasPrefix(s[i+size:], string(daggerAlef)):
+		return -1
+	case r == 'ى' && strings.HasPrefix(s[i+size:], string(daggerAlef)) &&
+		unicode.IsLetter(letterAfter(s, i+size)):
+		return 'ا'
+	case r != daggerAlef:
+		return n.fold(r)
+	case daggerDropped(s, i):
+		return -1
+	}
+	return 'ا'
+}
+
+// daggerDropped Check whether the simple script leaves out the long alef of
+// the dagger alef at i: over an alef maqsura, in ذلك, هذا, هؤلاء, أولئك,
+// لكن, إله and الرحمن
+func daggerDropped(s string, i int) bool {
+	prev, j := letterBefore(s, i)
+	prev2, _ := letterBefore(s, j)
+	next := letterAfter(s, i+utf8.RuneLen(daggerAlef))
+
+	switch prev {
+	case 'ى', 'ذ':
+		return true
+	case 'و':
+		// الربوٰا۟ is written الربا
+		return next == 'ا'
+	case 'ه':
+		return next == 'ذ' || next == 'ؤ'
+	case 'ل':
+		return next == 'ك' || next == 'ه' || (next == 'ئ' && prev2 == 'و')
+	case 'م':
+		return prev2 == 'ح' && next == 'ن'
+	}
+	return false
+}
+
+// letterBefore Return the letter before i in s and its offset, the marks
+// skipped, 0 when there is none
+func letterBefore(s string, i int) (rune, int) {
+	for i > 0 {
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		i -= size
+		if r == 'ٱ' || !strings.ContainsRune(quranicMarks, r) {
+			return r, i
+		}
+	}
+	return 0, 0
+}
+
+// letterAfter Return the letter at or after i in s, the marks skipped, 0
+// when there is none
+func letterAfter(s string, i int) rune {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == 'ٱ' || !strings.ContainsRune(quranicMarks, r) {
+			return r
+		}
+		i += size
+	}
+	return 0
+}
+
+// Normalize Apply the rules to s
+func (n *Normalizer) Normalize(s string) string {
+	if n == nil {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i, r := range s {
+		if f := n.foldAt(s, i, r); f >= 0 {
+			b.WriteRune(f)
+		}
+	}
+	return b.String()
+}
+
+// Map Apply the rules to s, also returning the offset in s of every byte of
+// the result followed by len(s), so positions can be mapped back
+func (n *Normalizer) Map(s string) (string, []int) {
+	var b strings.Builder
+	b.Grow(len(s))
+	offsets := make([]int, 0, len(s)+1)
+
+	for i, r := range s {
+		f := n.foldAt(s, i, r)
+		if f < 0 {
+			continue
+		}
+		b.WriteRune(f)
+		for k := utf8.RuneLen(f); k > 0; k-- {
+			offsets = append(offsets, i)
+		}
+	}
+
+	return b.String(), append(offsets, len(s))
+}
+
+// NewShadow Build the normalized shadow of the quran text
+func NewShadow(quran string, n *Normalizer) *Shadow {
+	sh := &Shadow{}
+	sh.Quran, sh.Offsets = n.Map(quran)
+	sh.Ayat = NewAyaTable(sh.Quran)
+	return sh
+}
+
+// restore Move matches found in the shadow back onto the quran text, plen
+// being the matched length when a match does not carry one
+func (sh *Shadow) restore(matches []SearchMatch, plen int, table *AyaTable) []SearchMatch {
+	for i, m := range matches {
+		end := m.Index + plen
+		if m.Length > 0 {
+			end = m.Index + m.Length
+		}
+		begin := sh.Offsets[m.Index]
+		end = sh.Offsets[minInt(end, len(sh.Offsets)-1)]
+
+		match := table.NewSearchMatch(begin, m.Time)
+		match.Length = end - begin
+		match.Pattern = m.Pattern
+		match.Distance = m.Distance
+		matches[i] = *match
+	}
+	return matches
+}
@@ -0,0 +1,95 @@
+package quransearch
+
+import (
+	"strings"
+	"testing"
+)
+
+func newTestXMLSearch(t *testing.T) *QuranSearch {
+	t.Helper()
+	qs, err := NewQuranSearchFromXML("../data/madina.xml")
+	if err != nil {
+		t.Fatalf("NewQuranSearchFromXML: %v", err)
+	}
+	return qs
+}
+
+func TestNormalizeDaggerAlef(t *testing.T) {
+	n := &Normalizer{Wasla: true, Tatweel: true, Diacritics: true, AlefMadda: true, Dagger: true}
+	tests := []struct {
+		word, want string
+	}{
+		{"ٱلْعَٰلَمِينَ", "العالمين"},
+		{"ٱلصَّلَوٰةَ", "الصلاة"},
+		{"ٱلْكِتَٰبُ", "الكتاب"},
+		{"ٱلسَّمَٰوَٰتِ", "السماوات"},
+		{"ذَٰلِكَ", "ذلك"},
+		{"هَٰذَا", "هذا"},
+		{"ٱلرَّحْمَٰنِ", "الرحمن"},
+		{"مَٰلِكِ", "مالك"},
+	}
+	for _, tt := range tests {
+		if got := n.Normalize(tt.word); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSearchXMLSimpleSpelling(t *testing.T) {
+	qs := newTestXMLSearch(t)
+	for _, q := range []string{"العالمين", "الصلاة", "الكتاب", "السماوات", "ذلك", "الرحمن"} {
+		if results := qs.Search(q, -1); len(results) == 0 {
+			t.Errorf("%s not found in the Uthmani text", q)
+		}
+	}
+}
+
+func TestNormalizerRules(t *testing.T) {
+	tests := []struct {
+		rule       string
+		n          Normalizer
+		text, want string
+	}{
+		{"Alef", Normalizer{Alef: true}, "أنزل إليك آمن", "انزل اليك امن"},
+		{"Wasla", Normalizer{Wasla: true}, "ٱلحمد", "الحمد"},
+		{"TaMarbuta", Normalizer{TaMarbuta: true}, "رحمة", "رحمه"},
+		{"AlefMaqsura", Normalizer{AlefMaqsura: true}, "موسى", "موسي"},
+		{"Hamza", Normalizer{Hamza: true}, "مؤمن سئل", "مءمن سءل"},
+		{"Tatweel", Normalizer{Tatweel: true}, "الـلـه", "الله"},
+		{"Diacritics", Normalizer{Diacritics: true}, "بِسْمِ ٱللَّهِ", "بسم ٱلله"},
+		{"Diacritics", Normalizer{Diacritics: true}, "آمن ٱلصَّلَوٰةَ ذَٰلِكَ", "آمن ٱلصلوة ذلك"},
+		{"AlefMadda", Normalizer{AlefMadda: true}, "آمَنَ", "امَنَ"},
+		{"Dagger", Normalizer{Dagger: true}, "ٱلْعَٰلَمِينَ", "ٱلْعَالَمِينَ"},
+		{"Dagger", Normalizer{Dagger: true}, "ٱلصَّلَوٰةَ ذَٰلِكَ", "ٱلصَّلَاةَ ذَلِكَ"},
+		{"Rasm", Normalizer{Rasm: true}, "بسم", "ٮسم"},
+	}
+	for _, tt := range tests {
+		if got := tt.n.Normalize(tt.text); got != tt.want {
+			t.Errorf("%s: Normalize(%q) = %q, want %q", tt.rule, tt.text, got, tt.want)
+		}
+		if got := (&Normalizer{}).Normalize(tt.text); got != tt.text {
+			t.Errorf("no rule: Normalize(%q) = %q", tt.text, got)
+		}
+	}
+}
+
+func TestQuranicMarks(t *testing.T) {
+	n := &Normalizer{Diacritics: true}
+	for word, want := range map[string]string{
+		"ءَامَنُوا۟":   "ءامنوا",
+		"ٱلظُّنُونَا۠": "ٱلظنونا",
+		"مِّنۢ":        "من",
+		"فِيهِۦ":       "فيه",
+	} {
+		if got := n.Normalize(word); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", word, got, want)
+		}
+	}
+
+	// the regex of the simple text queries keeps its own set
+	am := &AyaMatch{Indexes: []int{0}, MLen: len("بسم")}
+	am.StrBld.WriteString("بسم")
+	if regex := am.BuildUthmaniRegEx(); strings.ContainsAny(regex, "ۣۭ۪۟ۙ۫") {
+		t.Errorf("BuildUthmaniRegEx() = %q, with the signs of quranicMarks only", regex)
+	}
+}
@@ -0,0 +1,193 @@
+package quransearch
+
+// presentationForms the compatibility decompositions of the Arabic
+// Presentation Forms-A (U+FB50-U+FDFF) and -B (U+FE70-U+FEFC) blocks, the
+// positional forms folded to their letter and the ligatures expanded; the
+// isolated and medial forms of the marks are folded to the bare mark
+var presentationForms = map[rune]string{
+	// Forms-A
+	'\uFB50': "ٱ", '\uFB51': "ٱ", '\uFB52': "ٻ", '\uFB53': "ٻ",
+	'\uFB54': "ٻ", '\uFB55': "ٻ", '\uFB56': "پ", '\uFB57': "پ",
+	'\uFB58': "پ", '\uFB59': "پ", '\uFB5A': "ڀ", '\uFB5B': "ڀ",
+	'\uFB5C': "ڀ", '\uFB5D': "ڀ", '\uFB5E': "ٺ", '\uFB5F': "ٺ",
+	'\uFB60': "ٺ", '\uFB61': "ٺ", '\uFB62': "ٿ", '\uFB63': "ٿ",
+	'\uFB64': "ٿ", '\uFB65': "ٿ", '\uFB66': "ٹ", '\uFB67': "ٹ",
+	'\uFB68': "ٹ", '\uFB69': "ٹ", '\uFB6A': "ڤ", '\uFB6B': "ڤ",
+	'\uFB6C': "ڤ", '\uFB6D': "ڤ", '\uFB6E': "ڦ", '\uFB6F': "ڦ",
+	'\uFB70': "ڦ", '\uFB71': "ڦ", '\uFB72': "ڄ", '\uFB73': "ڄ",
+	'\uFB74': "ڄ", '\uFB75': "ڄ", '\uFB76': "ڃ", '\uFB77': "ڃ",
+	'\uFB78': "ڃ", '\uFB79': "ڃ", '\uFB7A': "چ", '\uFB7B': "چ",
+	'\uFB7C': "چ", '\uFB7D': "چ", '\uFB7E': "ڇ", '\uFB7F': "ڇ",
+	'\uFB80': "ڇ", '\uFB81': "ڇ", '\uFB82': "ڍ", '\uFB83': "ڍ",
+	'\uFB84': "ڌ", '\uFB85': "ڌ", '\uFB86': "ڎ", '\uFB87': "ڎ",
+	'\uFB88': "ڈ", '\uFB89': "ڈ", '\uFB8A': "ژ", '\uFB8B': "ژ",
+	'\uFB8C': "ڑ", '\uFB8D': "ڑ", '\uFB8E': "ک", '\uFB8F': "ک",
+	'\uFB90': "ک", '\uFB91': "ک", '\uFB92': "گ", '\uFB93': "گ",
+	'\uFB94': "گ", '\uFB95': "گ", '\uFB96': "ڳ", '\uFB97': "ڳ",
+	'\uFB98': "ڳ", '\uFB99': "ڳ", '\uFB9A': "ڱ", '\uFB9B': "ڱ",
+	'\uFB9C': "ڱ", '\uFB9D': "ڱ", '\uFB9E': "ں", '\uFB9F': "ں",
+	'\uFBA0': "ڻ", '\uFBA1': "ڻ", '\uFBA2': "ڻ", '\uFBA3': "ڻ",
+	'\uFBA4': "ۀ", '\uFBA5': "ۀ", '\uFBA6': "ہ", '\uFBA7': "ہ",
+	'\uFBA8': "ہ", '\uFBA9': "ہ", '\uFBAA': "ھ", '\uFBAB': "ھ",
+	'\uFBAC': "ھ", '\uFBAD': "ھ", '\uFBAE': "ے", '\uFBAF': "ے",
+	'\uFBB0': "ۓ", '\uFBB1': "ۓ", '\uFBD3': "ڭ", '\uFBD4': "ڭ",
+	'\uFBD5': "ڭ", '\uFBD6': "ڭ", '\uFBD7': "ۇ", '\uFBD8': "ۇ",
+	'\uFBD9': "ۆ", '\uFBDA': "ۆ", '\uFBDB': "ۈ", '\uFBDC': "ۈ",
+	'\uFBDD': "ٷ", '\uFBDE': "ۋ", '\uFBDF': "ۋ", '\uFBE0': "ۅ",
+	'\uFBE1': "ۅ", '\uFBE2': "ۉ", '\uFBE3': "ۉ", '\uFBE4': "ې",
+	'\uFBE5': "ې", '\uFBE6': "ې", '\uFBE7': "ې", '\uFBE8': "ى",
+	'\uFBE9': "ى", '\uFBEA': "ئا", '\uFBEB': "ئا", '\uFBEC': "ئە",
+	'\uFBED': "ئە", '\uFBEE': "ئو", '\uFBEF': "ئو", '\uFBF0': "ئۇ",
+	'\uFBF1': "ئۇ", '\uFBF2': "ئۆ", '\uFBF3': "ئۆ", '\uFBF4': "ئۈ",
+	'\uFBF5': "ئۈ", '\uFBF6': "ئې", '\uFBF7': "ئې", '\uFBF8': "ئې",
+	'\uFBF9': "ئى", '\uFBFA': "ئى", '\uFBFB': "ئى", '\uFBFC': "ی",
+	'\uFBFD': "ی", '\uFBFE': "ی", '\uFBFF': "ی", '\uFC00': "ئج",
+	'\uFC01': "ئح", '\uFC02': "ئم", '\uFC03': "ئى", '\uFC04': "ئي",
+	'\uFC05': "بج", '\uFC06': "بح", '\uFC07': "بخ", '\uFC08': "بم",
+	'\uFC09': "بى", '\uFC0A': "بي", '\uFC0B': "تج", '\uFC0C': "تح",
+	'\uFC0D': "تخ", '\uFC0E': "تم", '\uFC0F': "تى", '\uFC10': "تي",
+	'\uFC11': "ثج", '\uFC12': "ثم", '\uFC13': "ثى", '\uFC14': "ثي",
+	'\uFC15': "جح", '\uFC16': "جم", '\uFC17': "حج", '\uFC18': "حم",
+	'\uFC19': "خج", '\uFC1A': "خح", '\uFC1B': "خم", '\uFC1C': "سج",
+	'\uFC1D': "سح", '\uFC1E': "سخ", '\uFC1F': "سم", '\uFC20': "صح",
+	'\uFC21': "صم", '\uFC22': "ضج", '\uFC23': "ضح", '\uFC24': "ضخ",
+	'\uFC25': "ضم", '\uFC26': "طح", '\uFC27': "طم", '\uFC28': "ظم",
+	'\uFC29': "عج", '\uFC2A': "عم", '\uFC2B': "غج", '\uFC2C': "غم",
+	'\uFC2D': "فج", '\uFC2E': "فح", '\uFC2F': "فخ", '\uFC30': "فم",
+	'\uFC31': "فى", '\uFC32': "في", '\uFC33': "قح", '\uFC34': "قم",
+	'\uFC35': "قى", '\uFC36': "قي", '\uFC37': "كا", '\uFC38': "كج",
+	'\uFC39': "كح", '\uFC3A': "كخ", '\uFC3B': "كل", '\uFC3C': "كم",
+	'\uFC3D': "كى", '\uFC3E': "كي", '\uFC3F': "لج", '\uFC40': "لح",
+	'\uFC41': "لخ", '\uFC42': "لم", '\uFC43': "لى", '\uFC44': "لي",
+	'\uFC45': "مج", '\uFC46': "مح", '\uFC47': "مخ", '\uFC48': "مم",
+	'\uFC49': "مى", '\uFC4A': "مي", '\uFC4B': "نج", '\uFC4C': "نح",
+	'\uFC4D': "نخ", '\uFC4E': "نم", '\uFC4F': "نى", '\uFC50': "ني",
+	'\uFC51': "هج", '\uFC52': "هم", '\uFC53': "هى", '\uFC54': "هي",
+	'\uFC55': "يج", '\uFC56': "يح", '\uFC57': "يخ", '\uFC58': "يم",
+	'\uFC59': "يى", '\uFC5A': "يي", '\uFC5B': "ذٰ", '\uFC5C': "رٰ",
+	'\uFC5D': "ىٰ", '\uFC5E': "ٌّ", '\uFC5F': "ٍّ", '\uFC60': "َّ",
+	'\uFC61': "ُّ", '\uFC62': "ِّ", '\uFC63': "ّٰ", '\uFC64': "ئر",
+	'\uFC65': "ئز", '\uFC66': "ئم", '\uFC67': "ئن", '\uFC68': "ئى",
+	'\uFC69': "ئي", '\uFC6A': "بر", '\uFC6B': "بز", '\uFC6C': "بم",
+	'\uFC6D': "بن", '\uFC6E': "بى", '\uFC6F': "بي", '\uFC70': "تر",
+	'\uFC71': "تز", '\uFC72': "تم", '\uFC73': "تن", '\uFC74': "تى",
+	'\uFC75': "تي", '\uFC76': "ثر", '\uFC77': "ثز", '\uFC78': "ثم",
+	'\uFC79': "ثن", '\uFC7A': "ثى", '\uFC7B': "ثي", '\uFC7C': "فى",
+	'\uFC7D': "في", '\uFC7E': "قى", '\uFC7F': "قي", '\uFC80': "كا",
+	'\uFC81': "كل", '\uFC82': "كم", '\uFC83': "كى", '\uFC84': "كي",
+	'\uFC85': "لم", '\uFC86': "لى", '\uFC87': "لي", '\uFC88': "ما",
+	'\uFC89': "مم", '\uFC8A': "نر", '\uFC8B': "نز", '\uFC8C': "نم",
+	'\uFC8D': "نن", '\uFC8E': "نى", '\uFC8F': "ني", '\uFC90': "ىٰ",
+	'\uFC91': "ير", '\uFC92': "يز", '\uFC93': "يم", '\uFC94': "ين",
+	'\uFC95': "يى", '\uFC96': "يي", '\uFC97': "ئج", '\uFC98': "ئح",
+	'\uFC99': "ئخ", '\uFC9A': "ئم", '\uFC9B': "ئه", '\uFC9C': "بج",
+	'\uFC9D': "بح", '\uFC9E': "بخ", '\uFC9F': "بم", '\uFCA0': "به",
+	'\uFCA1': "تج", '\uFCA2': "تح", '\uFCA3': "تخ", '\uFCA4': "تم",
+	'\uFCA5': "ته", '\uFCA6': "ثم", '\uFCA7': "جح", '\uFCA8': "جم",
+	'\uFCA9': "حج", '\uFCAA': "حم", '\uFCAB': "خج", '\uFCAC': "خم",
+	'\uFCAD': "سج", '\uFCAE': "سح", '\uFCAF': "سخ", '\uFCB0': "سم",
+	'\uFCB1': "صح", '\uFCB2': "صخ", '\uFCB3': "صم", '\uFCB4': "ضج",
+	'\uFCB5': "ضح", '\uFCB6': "ضخ", '\uFCB7': "ضم", '\uFCB8': "طح",
+	'\uFCB9': "ظم", '\uFCBA': "عج", '\uFCBB': "عم", '\uFCBC': "غج",
+	'\uFCBD': "غم", '\uFCBE': "فج", '\uFCBF': "فح", '\uFCC0': "فخ",
+	'\uFCC1': "فم", '\uFCC2': "قح", '\uFCC3': "قم", '\uFCC4': "كج",
+	'\uFCC5': "كح", '\uFCC6': "كخ", '\uFCC7': "كل", '\uFCC8': "كم",
+	'\uFCC9': "لج", '\uFCCA': "لح", '\uFCCB': "لخ", '\uFCCC': "لم",
+	'\uFCCD': "له", '\uFCCE': "مج", '\uFCCF': "مح", '\uFCD0': "مخ",
+	'\uFCD1': "مم", '\uFCD2': "نج", '\uFCD3': "نح", '\uFCD4': "نخ",
+	'\uFCD5': "نم", '\uFCD6': "نه", '\uFCD7': "هج", '\uFCD8': "هم",
+	'\uFCD9': "هٰ", '\uFCDA': "يج", '\uFCDB': "يح", '\uFCDC': "يخ",
+	'\uFCDD': "يم", '\uFCDE': "يه", '\uFCDF': "ئم", '\uFCE0': "ئه",
+	'\uFCE1': "بم", '\uFCE2': "به", '\uFCE3': "تم", '\uFCE4': "ته",
+	'\uFCE5': "ثم", '\uFCE6': "ثه", '\uFCE7': "سم", '\uFCE8': "سه",
+	'\uFCE9': "شم", '\uFCEA': "شه", '\uFCEB': "كل", '\uFCEC': "كم",
+	'\uFCED': "لم", '\uFCEE': "نم", '\uFCEF': "نه", '\uFCF0': "يم",
+	'\uFCF1': "يه", '\uFCF2': "َّ", '\uFCF3': "ُّ", '\uFCF4': "ِّ",
+	'\uFCF5': "طى", '\uFCF6': "طي", '\uFCF7': "عى", '\uFCF8': "عي",
+	'\uFCF9': "غى", '\uFCFA': "غي", '\uFCFB': "سى", '\uFCFC': "سي",
+	'\uFCFD': "شى", '\uFCFE': "شي", '\uFCFF': "حى", '\uFD00': "حي",
+	'\uFD01': "جى", '\uFD02': "جي", '\uFD03': "خى", '\uFD04': "خي",
+	'\uFD05': "صى", '\uFD06': "صي", '\uFD07': "ضى", '\uFD08': "ضي",
+	'\uFD09': "شج", '\uFD0A': "شح", '\uFD0B': "شخ", '\uFD0C': "شم",
+	'\uFD0D': "شر", '\uFD0E': "سر", '\uFD0F': "صر", '\uFD10': "ضر",
+	'\uFD11': "طى", '\uFD12': "طي", '\uFD13': "عى", '\uFD14': "عي",
+	'\uFD15': "غى", '\uFD16': "غي", '\uFD17': "سى", '\uFD18': "سي",
+	'\uFD19': "شى", '\uFD1A': "شي", '\uFD1B': "حى", '\uFD1C': "حي",
+	'\uFD1D': "جى", '\uFD1E': "جي", '\uFD1F': "خى", '\uFD20': "خي",
+	'\uFD21': "صى", '\uFD22': "صي", '\uFD23': "ضى", '\uFD24': "ضي",
+	'\uFD25': "شج", '\uFD26': "شح", '\uFD27': "شخ", '\uFD28': "شم",
+	'\uFD29': "شر", '\uFD2A': "سر", '\uFD2B': "صر", '\uFD2C': "ضر",
+	'\uFD2D': "شج", '\uFD2E': "شح", '\uFD2F': "شخ", '\uFD30': "شم",
+	'\uFD31': "سه", '\uFD32': "شه", '\uFD33': "طم", '\uFD34': "سج",
+	'\uFD35': "سح", '\uFD36': "سخ", '\uFD37': "شج", '\uFD38': "شح",
+	'\uFD39': "شخ", '\uFD3A': "طم", '\uFD3B': "ظم", '\uFD3C': "اً",
+	'\uFD3D': "اً", '\uFD50': "تجم", '\uFD51': "تحج", '\uFD52': "تحج",
+	'\uFD53': "تحم", '\uFD54': "تخم", '\uFD55': "تمج", '\uFD56': "تمح",
+	'\uFD57': "تمخ", '\uFD58': "جمح", '\uFD59': "جمح", '\uFD5A': "حمي",
+	'\uFD5B': "حمى", '\uFD5C': "سحج", '\uFD5D': "سجح", '\uFD5E': "سجى",
+	'\uFD5F': "سمح", '\uFD60': "سمح", '\uFD61': "سمج", '\uFD62': "سمم",
+	'\uFD63': "سمم", '\uFD64': "صحح", '\uFD65': "صحح", '\uFD66': "صمم",
+	'\uFD67': "شحم", '\uFD68': "شحم", '\uFD69': "شجي", '\uFD6A': "شمخ",
+	'\uFD6B': "شمخ", '\uFD6C': "شمم", '\uFD6D': "شمم", '\uFD6E': "ضحى",
+	'\uFD6F': "ضخم", '\uFD70': "ضخم", '\uFD71': "طمح", '\uFD72': "طمح",
+	'\uFD73': "طمم", '\uFD74': "طمي", '\uFD75': "عجم", '\uFD76': "عمم",
+	'\uFD77': "عمم", '\uFD78': "عمى", '\uFD79': "غمم", '\uFD7A': "غمي",
+	'\uFD7B': "غمى", '\uFD7C': "فخم", '\uFD7D': "فخم", '\uFD7E': "قمح",
+	'\uFD7F': "قمم", '\uFD80': "لحم", '\uFD81': "لحي", '\uFD82': "لحى",
+	'\uFD83': "لجج", '\uFD84': "لجج", '\uFD85': "لخم", '\uFD86': "لخم",
+	'\uFD87': "لمح", '\uFD88': "لمح", '\uFD89': "محج", '\uFD8A': "محم",
+	'\uFD8B': "محي", '\uFD8C': "مجح", '\uFD8D': "مجم", '\uFD8E': "مخج",
+	'\uFD8F': "مخم", '\uFD92': "مجخ", '\uFD93': "همج", '\uFD94': "همم",
+	'\uFD95': "نحم", '\uFD96': "نحى", '\uFD97': "نجم", '\uFD98': "نجم",
+	'\uFD99': "نجى", '\uFD9A': "نمي", '\uFD9B': "نمى", '\uFD9C': "يمم",
+	'\uFD9D': "يمم", '\uFD9E': "بخي", '\uFD9F': "تجي", '\uFDA0': "تجى",
+	'\uFDA1': "تخي", '\uFDA2': "تخى", '\uFDA3': "تمي", '\uFDA4': "تمى",
+	'\uFDA5': "جمي", '\uFDA6': "جحى", '\uFDA7': "جمى", '\uFDA8': "سخى",
+	'\uFDA9': "صحي", '\uFDAA': "شحي", '\uFDAB': "ضحي", '\uFDAC': "لجي",
+	'\uFDAD': "لمي", '\uFDAE': "يحي", '\uFDAF': "يجي", '\uFDB0': "يمي",
+	'\uFDB1': "ممي", '\uFDB2': "قمي", '\uFDB3': "نحي", '\uFDB4': "قمح",
+	'\uFDB5': "لحم", '\uFDB6': "عمي", '\uFDB7': "كمي", '\uFDB8': "نجح",
+	'\uFDB9': "مخي", '\uFDBA': "لجم", '\uFDBB': "كمم", '\uFDBC': "لجم",
+	'\uFDBD': "نجح", '\uFDBE': "جحي", '\uFDBF': "حجي", '\uFDC0': "مجي",
+	'\uFDC1': "فمي", '\uFDC2': "بحي", '\uFDC3': "كمم", '\uFDC4': "عجم",
+	'\uFDC5': "صمم", '\uFDC6': "سخي", '\uFDC7': "نجي", '\uFDF0': "صلے",
+	'\uFDF1': "قلے", '\uFDF2': "الله", '\uFDF3': "اكبر", '\uFDF4': "محمد",
+	'\uFDF5': "صلعم", '\uFDF6': "رسول", '\uFDF7': "عليه", '\uFDF8': "وسلم",
+	'\uFDF9': "صلى", '\uFDFA': "صلى الله عليه وسلم", '\uFDFB': "جل جلاله", '\uFDFC': "ریال",
+	// Forms-B
+	'\uFE70': "ً", '\uFE71': "ً", '\uFE72': "ٌ", '\uFE74': "ٍ",
+	'\uFE76': "َ", '\uFE77': "َ", '\uFE78': "ُ", '\uFE79': "ُ",
+	'\uFE7A': "ِ", '\uFE7B': "ِ", '\uFE7C': "ّ", '\uFE7D': "ّ",
+	'\uFE7E': "ْ", '\uFE7F': "ْ", '\uFE80': "ء", '\uFE81': "آ",
+	'\uFE82': "آ", '\uFE83': "أ", '\uFE84': "أ", '\uFE85': "ؤ",
+	'\uFE86': "ؤ", '\uFE87': "إ", '\uFE88': "إ", '\uFE89': "ئ",
+	'\uFE8A': "ئ", '\uFE8B': "ئ", '\uFE8C': "ئ", '\uFE8D': "ا",
+	'\uFE8E': "ا", '\uFE8F': "ب", '\uFE90': "ب", '\uFE91': "ب",
+	'\uFE92': "ب", '\uFE93': "ة", '\uFE94': "ة", '\uFE95': "ت",
+	'\uFE96': "ت", '\uFE97': "ت", '\uFE98': "ت", '\uFE99': "ث",
+	'\uFE9A': "ث", '\uFE9B': "ث", '\uFE9C': "ث", '\uFE9D': "ج",
+	'\uFE9E': "ج", '\uFE9F': "ج", '\uFEA0': "ج", '\uFEA1': "ح",
+	'\uFEA2': "ح", '\uFEA3': "ح", '\uFEA4': "ح", '\uFEA5': "خ",
+	'\uFEA6': "خ", '\uFEA7': "خ", '\uFEA8': "خ", '\uFEA9': "د",
+	'\uFEAA': "د", '\uFEAB': "ذ", '\uFEAC': "ذ", '\uFEAD': "ر",
+	'\uFEAE': "ر", '\uFEAF': "ز", '\uFEB0': "ز", '\uFEB1': "س",
+	'\uFEB2': "س", '\uFEB3': "س", '\uFEB4': "س", '\uFEB5': "ش",
+	'\uFEB6': "ش", '\uFEB7': "ش", '\uFEB8': "ش", '\uFEB9': "ص",
+	'\uFEBA': "ص", '\uFEBB': "ص", '\uFEBC': "ص", '\uFEBD': "ض",
+	'\uFEBE': "ض", '\uFEBF': "ض", '\uFEC0': "ض", '\uFEC1': "ط",
+	'\uFEC2': "ط", '\uFEC3': "ط", '\uFEC4': "ط", '\uFEC5': "ظ",
+	'\uFEC6': "ظ", '\uFEC7': "ظ", '\uFEC8': "ظ", '\uFEC9': "ع",
+	'\uFECA': "ع", '\uFECB': "ع", '\uFECC': "ع", '\uFECD': "غ",
+	'\uFECE': "غ", '\uFECF': "غ", '\uFED0': "غ", '\uFED1': "ف",
+	'\uFED2': "ف", '\uFED3': "ف", '\uFED4': "ف", '\uFED5': "ق",
+	'\uFED6': "ق", '\uFED7': "ق", '\uFED8': "ق", '\uFED9': "ك",
+	'\uFEDA': "ك", '\uFEDB': "ك", '\uFEDC': "ك", '\uFEDD': "ل",
+	'\uFEDE': "ل", '\uFEDF': "ل", '\uFEE0': "ل", '\uFEE1': "م",
+	'\uFEE2': "م", '\uFEE3': "م", '\uFEE4': "م", '\uFEE5': "ن",
+	'\uFEE6': "ن", '\uFEE7': "ن", '\uFEE8': "ن", '\uFEE9': "ه",
+	'\uFEEA': "ه", '\uFEEB': "ه", '\uFEEC': "ه", '\uFEED': "و",
+	'\uFEEE': "و", '\uFEEF': "ى", '\uFEF0': "ى", '\uFEF1': "ي",
+	'\uFEF2': "ي", '\uFEF3': "ي", '\uFEF4': "ي", '\uFEF5': "لآ",
+	'\uFEF6': "لآ", '\uFEF7': "لأ", '\uFEF8': "لأ", '\uFEF9': "لإ",
+	'\uFEFA': "لإ", '\uFEFB': "لا", '\uFEFC': "لا",
+}
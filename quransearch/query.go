@@ -0,0 +1,429 @@
+package quransearch
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	tokEOF = iota
+	tokTerm
+	tokPhrase
+	tokAnd
+	tokOr
+	tokNot
+	tokOpen
+	tokClose
+	tokNear
+)
+
+const NEAR_OPERATOR = "NEAR/"
+
+// queryToken a token of a boolean query, pos being its offset in runes
+type queryToken struct {
+	kind int
+	text string
+	pos  int
+}
+
+// queryParser recursive descent parser of the grammar
+//
+//	or      = and { "OR" and }
+//	and     = near { ["AND"] near | "NOT" near }
+//	near    = unary [ "NEAR/k" unary | "NEAR/k+" unary ]
+//	unary   = "NOT" unary | primary
+//	primary = "(" or ")" | "\"" phrase "\"" | term
+type queryParser struct {
+	tokens []queryToken
+	next   int
+}
+
+func (e *QuerySyntaxError) Error() string {
+	return fmt.Sprintf("ParseQuery: %s at %d", e.Msg, e.Pos)
+}
+
+// ParseQuery Parse a query such as (موسى OR فرعون) AND "البحر" NOT الغرق:
+// AND binds tighter than OR and may be left out, a NOT between two terms
+// means AND NOT, and a quoted phrase is searched as a whole. موسى NEAR/5
+// فرعون asks for the terms within 5 words in an aya, NEAR/5+ also across
+// the end of an aya into the next one.
+func ParseQuery(q string) (QueryNode, error) {
+	tokens, err := lexQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	qp := &queryParser{tokens: tokens}
+	if qp.peek().kind == tokEOF {
+		return nil, &QuerySyntaxError{Pos: 0, Msg: "empty query"}
+	}
+	node, err := qp.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := qp.peek(); t.kind != tokEOF {
+		return nil, &QuerySyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return node, nil
+}
+
+func lexQuery(q string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(q)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokOpen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokClose, text: ")", pos: i})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, &QuerySyntaxError{Pos: i, Msg: "unclosed quote"}
+			}
+			phrase := strings.Join(strings.Fields(string(runes[i+1:end])), " ")
+			if phrase == "" {
+				return nil, &QuerySyntaxError{Pos: i, Msg: "empty phrase"}
+			}
+			tokens = append(tokens, queryToken{kind: tokPhrase, text: phrase, pos: i})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()"`, runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+			kind := tokTerm
+			switch word {
+			case "AND":
+				kind = tokAnd
+			case "OR":
+				kind = tokOr
+			case "NOT":
+				kind = tokNot
+			default:
+				if strings.HasPrefix(word, NEAR_OPERATOR) {
+					kind = tokNear
+				}
+			}
+			tokens = append(tokens, queryToken{kind: kind, text: word, pos: i})
+			i = end
+		}
+	}
+	return append(tokens, queryToken{kind: tokEOF, text: "end of query", pos: len(runes)}), nil
+}
+
+func (qp *queryParser) peek() queryToken {
+	return qp.tokens[qp.next]
+}
+
+func (qp *queryParser) take() queryToken {
+	t := qp.tokens[qp.next]
+	if t.kind != tokEOF {
+		qp.next++
+	}
+	return t
+}
+
+func (qp *queryParser) parseOr() (QueryNode, error) {
+	left, err := qp.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for qp.peek().kind == tokOr {
+		qp.take()
+		right, err := qp.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &QueryOr{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (qp *queryParser) parseAnd() (QueryNode, error) {
+	left, err := qp.parseNear()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch qp.peek().kind {
+		case tokAnd:
+			qp.take()
+		case tokNot:
+			// a NOT between two operands excludes the right one
+		case tokTerm, tokPhrase, tokOpen:
+		default:
+			return left, nil
+		}
+		right, err := qp.parseNear()
+		if err != nil {
+			return nil, err
+		}
+		left = &QueryAnd{Left: left, Right: right}
+	}
+}
+
+func (qp *queryParser) parseNear() (QueryNode, error) {
+	left, err := qp.parseUnary()
+	if err != nil || qp.peek().kind != tokNear {
+		return left, err
+	}
+	op := qp.take()
+	spec := strings.TrimPrefix(op.text, NEAR_OPERATOR)
+	cross := strings.HasSuffix(spec, "+")
+	k, err := strconv.Atoi(strings.TrimSuffix(spec, "+"))
+	if err != nil || k < 1 {
+		return nil, &QuerySyntaxError{Pos: op.pos, Msg: fmt.Sprintf("bad distance in %q", op.text)}
+	}
+	right, err := qp.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	a, okLeft := left.(*QueryTerm)
+	b, okRight := right.(*QueryTerm)
+	if !okLeft || !okRight {
+		return nil, &QuerySyntaxError{Pos: op.pos, Msg: "NEAR takes a term or a phrase on each side"}
+	}
+	if qp.peek().kind == tokNear {
+		return nil, &QuerySyntaxError{Pos: qp.peek().pos, Msg: "NEAR can not be chained"}
+	}
+	return &QueryNear{Left: a, Right: b, Distance: k, CrossAya: cross}, nil
+}
+
+func (qp *queryParser) parseUnary() (QueryNode, error) {
+	if qp.peek().kind == tokNot {
+		qp.take()
+		node, err := qp.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &QueryNot{Node: node}, nil
+	}
+	return qp.parsePrimary()
+}
+
+func (qp *queryParser) parsePrimary() (QueryNode, error) {
+	t := qp.take()
+	switch t.kind {
+	case tokTerm:
+		return &QueryTerm{Text: t.text, Pos: t.pos}, nil
+	case tokPhrase:
+		return &QueryTerm{Text: t.text, Phrase: true, Pos: t.pos}, nil
+	case tokOpen:
+		node, err := qp.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if qp.take().kind != tokClose {
+			return nil, &QuerySyntaxError{Pos: t.pos, Msg: "unclosed ("}
+		}
+		return node, nil
+	}
+	return nil, &QuerySyntaxError{Pos: t.pos, Msg: fmt.Sprintf("expected a term, got %q", t.text)}
+}
+
+func (t *QueryTerm) String() string {
+	if t.Phrase {
+		return `"` + t.Text + `"`
+	}
+	return t.Text
+}
+
+func (n *QueryAnd) String() string {
+	return "(" + n.Left.String() + " AND " + n.Right.String() + ")"
+}
+
+func (n *QueryOr) String() string {
+	return "(" + n.Left.String() + " OR " + n.Right.String() + ")"
+}
+
+func (n *QueryNot) String() string {
+	return "NOT " + n.Node.String()
+}
+
+func (n *QueryNear) String() string {
+	op := NEAR_OPERATOR + strconv.Itoa(n.Distance)
+	if n.CrossAya {
+		op += "+"
+	}
+	return "(" + n.Left.String() + " " + op + " " + n.Right.String() + ")"
+}
+
+// ayaSet a set of ayat, by their position in the aya table
+type ayaSet map[int]bool
+
+// queryEval evaluation of a query, the matches of each term kept for the
+// highlights and the smallest NEAR distance of each aya
+type queryEval struct {
+	qs        *QuranSearch
+	matches   map[string][]SearchMatch
+	distances map[int]int
+}
+
+// SearchQuery Search the ayat matching a boolean query, see ParseQuery, in
+// text order; every match of a term that is not negated is highlighted and
+// Nfo.WordDistance holds the smallest NEAR distance of the aya. A query that
+// is a single NEAR returns each hit instead, as SearchNear does.
+func (qs *QuranSearch) SearchQuery(q string, max int) ([]AyaMatch, error) {
+	node, err := ParseQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	ev := qs.newQueryEval()
+	if near, ok := node.(*QueryNear); ok {
+		return qs.buildNearResults(ev.near(near), max), nil
+	}
+	set := ev.eval(node)
+
+	ayat := make([]int, 0, len(set))
+	for n := range set {
+		ayat = append(ayat, n)
+	}
+	sort.Ints(ayat)
+
+	var positive []*QueryTerm
+	collectTerms(node, false, &positive)
+	spans := make(map[int][]SearchMatch)
+	seen := make(map[[2]int]bool)
+	for _, t := range positive {
+		for _, m := range ev.find(t) {
+			n := qs.Ayat.Find(m.Index)
+			if set[n] && !seen[[2]int{m.Index, m.Length}] {
+				// terms such as ﷲ and الله find the same spans
+				seen[[2]int{m.Index, m.Length}] = true
+				spans[n] = append(spans[n], m)
+			}
+		}
+	}
+
+	var matches []SearchMatch
+	for _, n := range ayat {
+		if len(spans[n]) == 0 {
+			// only negated terms, the aya is shown without highlight
+			matches = append(matches, *qs.Ayat.NewSearchMatch(qs.Ayat.Ayat[n].Begin, 0))
+			continue
+		}
+		sort.Slice(spans[n], func(i, j int) bool {
+			return spans[n][i].Index < spans[n][j].Index
+		})
+		matches = append(matches, spans[n]...)
+	}
+	results := qs.buildAyaResults(matches, max)
+	for i := range results {
+		if d, ok := ev.distances[qs.Ayat.Find(results[i].Nfo.Begin)]; ok {
+			results[i].Nfo.WordDistance = d
+		}
+	}
+	return results, nil
+}
+
+func (qs *QuranSearch) newQueryEval() *queryEval {
+	return &queryEval{
+		qs:        qs,
+		matches:   make(map[string][]SearchMatch),
+		distances: make(map[int]int),
+	}
+}
+
+// find Return the matches of a term, searched once
+func (ev *queryEval) find(t *QueryTerm) []SearchMatch {
+	if m, ok := ev.matches[t.Text]; ok {
+		return m
+	}
+	p := ev.qs.sanitize(t.Text)
+	m := ev.qs.find(p, -1)
+	kept := m[:0]
+	for _, match := range m {
+		// a term of digits also matches the numbers heading the aya lines
+		if n := ev.qs.Ayat.Find(match.Index); n < 0 || match.Index < ev.qs.Ayat.Ayat[n].Begin {
+			continue
+		}
+		if match.Length == 0 {
+			match.Length = len(p)
+		}
+		kept = append(kept, match)
+	}
+	ev.matches[t.Text] = kept
+	return kept
+}
+
+func (ev *queryEval) eval(node QueryNode) ayaSet {
+	set := make(ayaSet)
+	switch n := node.(type) {
+	case *QueryTerm:
+		for _, m := range ev.find(n) {
+			set[ev.qs.Ayat.Find(m.Index)] = true
+		}
+	case *QueryAnd:
+		left, right := ev.eval(n.Left), ev.eval(n.Right)
+		for a := range left {
+			if right[a] {
+				set[a] = true
+			}
+		}
+	case *QueryOr:
+		for a := range ev.eval(n.Left) {
+			set[a] = true
+		}
+		for a := range ev.eval(n.Right) {
+			set[a] = true
+		}
+	case *QueryNear:
+		for _, hit := range ev.near(n) {
+			for _, a := range []int{hit.first, hit.second} {
+				set[a] = true
+				if d, ok := ev.distances[a]; !ok || hit.distance < d {
+					ev.distances[a] = hit.distance
+				}
+			}
+		}
+	case *QueryNot:
+		excluded := ev.eval(n.Node)
+		for a := range ev.qs.Ayat.Ayat {
+			if !excluded[a] {
+				set[a] = true
+			}
+		}
+	}
+	return set
+}
+
+// collectTerms Gather the terms under an even number of NOTs, each text
+// once so that "X OR X" highlights X once
+func collectTerms(node QueryNode, negated bool, terms *[]*QueryTerm) {
+	switch n := node.(type) {
+	case *QueryTerm:
+		if negated {
+			return
+		}
+		for _, t := range *terms {
+			if t.Text == n.Text {
+				return
+			}
+		}
+		*terms = append(*terms, n)
+	case *QueryAnd:
+		collectTerms(n.Left, negated, terms)
+		collectTerms(n.Right, negated, terms)
+	case *QueryOr:
+		collectTerms(n.Left, negated, terms)
+		collectTerms(n.Right, negated, terms)
+	case *QueryNot:
+		collectTerms(n.Node, !negated, terms)
+	case *QueryNear:
+		collectTerms(n.Left, negated, terms)
+		collectTerms(n.Right, negated, terms)
+	}
+}
@@ -0,0 +1,145 @@
+package quransearch
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"موسى فرعون", "(موسى AND فرعون)"},
+		{"موسى OR فرعون هارون", "(موسى OR (فرعون AND هارون))"},
+		{`(موسى OR فرعون) AND "البحر" NOT الغرق`, `(((موسى OR فرعون) AND "البحر") AND NOT الغرق)`},
+		{`"رب   العالمين"`, `"رب العالمين"`},
+		{"موسى NEAR/5 فرعون", "(موسى NEAR/5 فرعون)"},
+		{"موسى NEAR/3+ هارون", "(موسى NEAR/3+ هارون)"},
+	}
+	for _, tt := range tests {
+		node, err := ParseQuery(tt.query)
+		if err != nil {
+			t.Errorf("ParseQuery(%q): %v", tt.query, err)
+			continue
+		}
+		if got := node.String(); got != tt.want {
+			t.Errorf("ParseQuery(%q) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+	}{
+		{"", 0},
+		{"(موسى", 0},
+		{`موسى "فرعون`, 5},
+		{"موسى OR", 7},
+		{"موسى )", 5},
+	}
+	for _, tt := range tests {
+		_, err := ParseQuery(tt.query)
+		var syntax *QuerySyntaxError
+		if !errors.As(err, &syntax) {
+			t.Errorf("ParseQuery(%q) error %v, want a QuerySyntaxError", tt.query, err)
+			continue
+		}
+		if syntax.Pos != tt.pos {
+			t.Errorf("ParseQuery(%q) error at %d, want %d: %v", tt.query, syntax.Pos, tt.pos, err)
+		}
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	qs := newTestSearch(t)
+	has := func(ao AyaOffset, term string) bool {
+		return strings.Contains(qs.Quran[ao.Begin:ao.End], term)
+	}
+	tests := []struct {
+		query string
+		want  func(ao AyaOffset) bool
+	}{
+		{"موسى AND فرعون", func(ao AyaOffset) bool { return has(ao, "موسى") && has(ao, "فرعون") }},
+		{"موسى OR هارون", func(ao AyaOffset) bool { return has(ao, "موسى") || has(ao, "هارون") }},
+		{"موسى NOT فرعون", func(ao AyaOffset) bool { return has(ao, "موسى") && !has(ao, "فرعون") }},
+		{`"رب العالمين" (الله OR الرحمن)`, func(ao AyaOffset) bool {
+			return has(ao, "رب العالمين") && (has(ao, "الله") || has(ao, "الرحمن"))
+		}},
+	}
+	for _, tt := range tests {
+		results, err := qs.SearchQuery(tt.query, -1)
+		if err != nil {
+			t.Errorf("SearchQuery(%q): %v", tt.query, err)
+			continue
+		}
+
+		var want []AyaOffset
+		for _, ao := range qs.Ayat.Ayat {
+			if tt.want(ao) {
+				want = append(want, ao)
+			}
+		}
+		if len(results) != len(want) || len(want) == 0 {
+			t.Errorf("SearchQuery(%q) = %d ayat, want %d", tt.query, len(results), len(want))
+			continue
+		}
+		for i, r := range results {
+			if r.Nfo.Surah != want[i].Surah || r.Nfo.Aya != want[i].Aya {
+				t.Errorf("SearchQuery(%q) result %d is %d:%d, want %d:%d", tt.query, i,
+					r.Nfo.Surah, r.Nfo.Aya, want[i].Surah, want[i].Aya)
+				break
+			}
+		}
+	}
+}
+
+func TestSearchQueryHeaders(t *testing.T) {
+	qs := newTestSearch(t)
+	// the digits of the aya lines are not part of the text
+	for _, q := range []string{"255", "2 OR 255", "NOT 255 AND 255"} {
+		results, err := qs.SearchQuery(q, -1)
+		if err != nil || len(results) != 0 {
+			t.Errorf("SearchQuery(%q) = %d ayat, %v", q, len(results), err)
+		}
+	}
+	results, err := qs.SearchQuery("الصمد OR 112", -1)
+	if err != nil || len(results) != 1 || results[0].Nfo.Surah != 112 || results[0].Nfo.Aya != 2 {
+		t.Errorf("SearchQuery(الصمد OR 112) = %d ayat, %v", len(results), err)
+	}
+	if results, _ := qs.SearchQuery("NOT 255", -1); len(results) != len(qs.Ayat.Ayat) {
+		t.Errorf("NOT 255 found %d ayat, want all %d", len(results), len(qs.Ayat.Ayat))
+	}
+}
+
+func TestSearchQuerySpans(t *testing.T) {
+	qs := newTestSearch(t)
+	tests := []struct {
+		query, single string
+	}{
+		{"الرحمن OR الرحمن", "الرحمن"},
+		{"الرحمن AND الرحمن", "الرحمن"},
+		{"الله OR ﷲ", "الله"},
+	}
+	for _, tt := range tests {
+		single, err := qs.SearchQuery(tt.single, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results, err := qs.SearchQuery(tt.query, -1)
+		if err != nil || len(results) != len(single) {
+			t.Errorf("SearchQuery(%q) = %d ayat, want %d, %v", tt.query, len(results), len(single), err)
+			continue
+		}
+		for i, r := range results {
+			if !reflect.DeepEqual(r.Indexes, single[i].Indexes) || !reflect.DeepEqual(r.Lens, single[i].Lens) {
+				t.Errorf("SearchQuery(%q) highlights %v in %d:%d, want %v", tt.query, r.Indexes,
+					r.Nfo.Surah, r.Nfo.Aya, single[i].Indexes)
+				break
+			}
+		}
+	}
+}
@@ -7,54 +7,99 @@ import (
 	"log"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
 
 const (
-	DEF_SEARCH_LIMIT   = 10
-	DEF_BUFFER_SIZE    = 1024 * 4
-	MIN_PATTERN_LEN    = 1
-	MAX_INDEX_OF_LEN   = 10
-	METHOD_INDEX_OF    = 0
-	METHOD_BOYER_MOORE = 1
-	METHOD_REGEX       = 2
-	METHOD_BRUTE_FORCE = 3
-	METHOD_DEFAULT     = METHOD_REGEX
+	DEF_SEARCH_LIMIT    = 10
+	DEF_BUFFER_SIZE     = 1024 * 4
+	MIN_PATTERN_LEN     = 1
+	MAX_INDEX_OF_LEN    = 10
+	METHOD_INDEX_OF     = 0
+	METHOD_BOYER_MOORE  = 1
+	METHOD_REGEX        = 2
+	METHOD_BRUTE_FORCE  = 3
+	METHOD_WORD_INDEX   = 4
+	METHOD_SUFFIX_ARRAY = 5
+	METHOD_AHO_CORASICK = 6
+	METHOD_FUZZY        = 7
+	METHOD_VOCALIZED    = 8
+	METHOD_STEM         = 9
+	METHOD_WILDCARD     = 10
+	METHOD_DEFAULT      = METHOD_REGEX
 )
 
-/*
-	TODO: Fix the Boyer Moore and IndexOf methods they giving wrong results
-*/
-
 type QuranSearch struct {
 	Reader        *bufio.Reader
 	Quran         string
+	Ayat          *AyaTable
+	Words         *WordIndex
+	Suffixes      *SuffixArray
+	Normalizer    *Normalizer
+	Shadow        *Shadow
+	Morphology    *Morphology
 	CurrentMethod int
+	MatchMode     int // MATCH_ANYWHERE, MATCH_WHOLE_WORD, ...
+	MaxDistance   float64
+	Uthmani       bool
+	FoldVariants  bool // fold Persian and Urdu letters of the queries
 	SurahAyaNbrs  bool
 	AyaBegin      bool
 	SpecialCases  []SearchMatch
 }
 
 func NewQuranSearch(filePath string) (*QuranSearch, error) {
-	qs := &QuranSearch{CurrentMethod: METHOD_DEFAULT}
+	qs := &QuranSearch{CurrentMethod: METHOD_DEFAULT, FoldVariants: true}
 	err := qs.readFile(filePath)
 	if err != nil {
 		return nil, err
 	}
+	qs.Ayat = NewAyaTable(qs.Quran)
 	return qs, nil
 }
 
 func NewQuranSearchWithText(quranFile embed.FS) (*QuranSearch, error) {
-	qs := &QuranSearch{CurrentMethod: METHOD_DEFAULT}
+	qs := &QuranSearch{CurrentMethod: METHOD_DEFAULT, FoldVariants: true}
 	file, err := quranFile.ReadFile("data/quran.txt")
 	if err != nil {
 		return nil, fmt.Errorf("error reading quran file: %v", err)
 	}
 	qs.Quran = string(file)
+	qs.Ayat = NewAyaTable(qs.Quran)
+	return qs, nil
+}
+
+// NewQuranSearchFromQuran Constructor searching the text of a parsed Quran,
+// such as the Uthmani madina.xml, ignoring its diacritics and marks
+func NewQuranSearchFromQuran(quran *Quran) (*QuranSearch, error) {
+	if len(quran.Surahs) == 0 {
+		return nil, fmt.Errorf("NewQuranSearchFromQuran: no surah in %s quran", quran.Version)
+	}
+	qs := &QuranSearch{CurrentMethod: METHOD_DEFAULT, Uthmani: true, FoldVariants: true}
+
+	var sb strings.Builder
+	for _, surah := range quran.Surahs {
+		for _, ayah := range surah.Ayahs {
+			sb.WriteString(fmt.Sprintf("%d|%d|%s\n", surah.No, ayah.No, ayah.Text))
+		}
+	}
+	qs.Quran = sb.String()
+	qs.Ayat = NewAyaTable(qs.Quran)
+	qs.SetNormalizer(&Normalizer{Wasla: true, Tatweel: true, Diacritics: true, AlefMadda: true, Dagger: true})
 	return qs, nil
 }
 
+// NewQuranSearchFromXML Constructor searching the quran XML file at filePath
+func NewQuranSearchFromXML(filePath string) (*QuranSearch, error) {
+	var quran = &Quran{}
+	if err := ParseQuranXML(filePath, quran); err != nil {
+		return nil, err
+	}
+	return NewQuranSearchFromQuran(quran)
+}
+
 func (qs *QuranSearch) readFile(filePath string) error {
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -83,81 +128,344 @@ func (qs *QuranSearch) readFile(filePath string) error {
 	return nil
 }
 
+// Search Search the pattern with the current method once sanitized; a Latin
+// query without hits is retried as typed on the Arabic layout of a QWERTY
+// then an AZERTY keyboard, the results of the retry holding it in DidYouMean
 func (qs *QuranSearch) Search(p string, max int) []AyaMatch {
+	p, transliterated := fromTransliteration(qs.fold(qs.sanitize(p)))
+
+	results := qs.search(p, max)
+	if len(results) > 0 || transliterated || IsMorphQuery(p) || !isWrongLayout(p) {
+		return results
+	}
+	for _, remapped := range []string{FromQwerty(p), FromAzerty(p)} {
+		if results = qs.search(remapped, max); len(results) > 0 {
+			for i := range results {
+				results[i].DidYouMean = remapped
+			}
+			return results
+		}
+	}
+	return results
+}
+
+func (qs *QuranSearch) search(p string, max int) []AyaMatch {
 	if len(p) < MIN_PATTERN_LEN {
 		return nil
 	}
 
-	if qs.oneLetterSpecialCase(p) || qs.twoLettersSpecialCase(p) {
-		return qs.buildResults(qs.SpecialCases, len(p))
+	if IsMorphQuery(p) {
+		results, _ := qs.SearchMorphology(p, max)
+		return results
 	}
 
-	var matches []SearchMatch
+	if strings.HasPrefix(p, WAZN_PREFIX) {
+		results, _ := qs.SearchWazn(p, max)
+		return results
+	}
+
+	// the special cases are the isolated letters as quran.txt writes them
+	if !qs.Uthmani && (qs.oneLetterSpecialCase(p) || qs.twoLettersSpecialCase(p)) {
+		matches := filterMatchMode(qs.Quran, qs.SpecialCases, len(p), qs.MatchMode, max)
+		if max >= 0 && len(matches) > max {
+			matches = matches[:max]
+		}
+		return qs.buildResults(matches, len(p))
+	}
+
+	// a query copied from a mushaf is rewritten for the simple script
+	if !qs.Uthmani && qs.CurrentMethod != METHOD_VOCALIZED && IsUthmaniQuery(p) {
+		return qs.buildResults(qs.findSimple(p, max), len(p))
+	}
+
+	return qs.buildResults(qs.find(p, max), len(p))
+}
+
+// find Run the current method over the text, or over its normalized shadow
+// when a normalizer is set
+func (qs *QuranSearch) find(p string, max int) []SearchMatch {
+	return qs.findWith(qs.CurrentMethod, p, max)
+}
 
-	switch qs.CurrentMethod {
+// findWith Run the method numbered id as find does the current one, over
+// each spelling of the query
+func (qs *QuranSearch) findWith(id int, p string, max int) []SearchMatch {
+	return qs.findSpellings(qs.fold(p), max, func(p string, max int) []SearchMatch {
+		return qs.findSpelling(id, p, max)
+	})
+}
+
+// findSpelling Run the method numbered id for one spelling of the query
+func (qs *QuranSearch) findSpelling(id int, p string, max int) []SearchMatch {
+	if id == METHOD_VOCALIZED {
+		// the diacritics are what this method compares
+		matches := qs.method(id, qs.Ayat).Search(qs.Quran, p, qs.limit(max))
+		return filterMatchMode(qs.Quran, matches, len(p), qs.MatchMode, max)
+	}
+	text, table := qs.corpus()
+	p = qs.Normalizer.Normalize(p)
+	if p == "" {
+		// a query of marks only, all of them removed
+		return nil
+	}
+	matches := qs.method(id, table).Search(text, p, qs.limit(max))
+	return qs.restore(filterMatchMode(text, matches, len(p), qs.MatchMode, max), len(p))
+}
+
+// findSimple Search the simple script spellings of a query typed in Uthmani
+// spelling, whatever the current method, over the text itself as the regex
+// tells the hamza seats and the alef madda apart
+func (qs *QuranSearch) findSimple(p string, max int) []SearchMatch {
+	return qs.findSpellings(qs.fold(p), max, func(p string, max int) []SearchMatch {
+		pattern := BuildSimpleRegEx(p)
+		if pattern == "" {
+			// a query of marks or tatweel only
+			return nil
+		}
+		regex := RegexMethod{Table: qs.Ayat}
+		matches := regex.Search(qs.Quran, pattern, qs.limit(max))
+		return filterMatchMode(qs.Quran, matches, 0, qs.MatchMode, max)
+	})
+}
+
+// method Return the search method numbered id, resolving matches with table
+func (qs *QuranSearch) method(id int, table *AyaTable) SearchMethod {
+	switch id {
 	case METHOD_BOYER_MOORE:
-		boyerMoore := BoyerMooreMethod{}
-		matches = boyerMoore.Search(qs.Quran, p, max)
+		return &BoyerMooreMethod{Table: table}
 	case METHOD_REGEX:
-		regex := RegexMethod{}
-		matches = regex.Search(qs.Quran, p, max)
+		return &RegexMethod{Table: table}
 	case METHOD_BRUTE_FORCE:
-		bruteForce := BruteForceMethod{}
-		matches = bruteForce.Search(qs.Quran, p, max)
+		return &BruteForceMethod{Table: table}
+	case METHOD_WORD_INDEX:
+		return &WordIndexMethod{Index: qs.wordIndex(), Table: table}
+	case METHOD_SUFFIX_ARRAY:
+		return &SuffixArrayMethod{Index: qs.suffixArray(), Table: table}
+	case METHOD_AHO_CORASICK:
+		return &AhoCorasickMethod{Table: table}
+	case METHOD_FUZZY:
+		return &FuzzyMethod{MaxDistance: qs.MaxDistance, Table: table}
+	case METHOD_STEM:
+		return &StemMethod{Index: qs.wordIndex(), Table: table}
+	case METHOD_VOCALIZED:
+		return &VocalizedMethod{Table: table}
+	case METHOD_WILDCARD:
+		return &WildcardMethod{Table: table}
 	case METHOD_INDEX_OF:
-		indexOf := indexOfMethod{}
-		matches = indexOf.Search(qs.Quran, p, max)
+		return indexOfMethod{Table: table}
 	default:
-		indexOf := indexOfMethod{}
-		matches = indexOf.Search(qs.Quran, p, max)
+		return indexOfMethod{Table: table}
+	}
+}
+
+// corpus Return the text the methods run over with its aya table
+func (qs *QuranSearch) corpus() (string, *AyaTable) {
+	if qs.Shadow != nil {
+		return qs.Shadow.Quran, qs.Shadow.Ayat
+	}
+	return qs.Quran, qs.Ayat
+}
+
+// restore Move matches found in the shadow back onto the text
+func (qs *QuranSearch) restore(matches []SearchMatch, plen int) []SearchMatch {
+	if qs.Shadow == nil {
+		return matches
+	}
+	return qs.Shadow.restore(matches, plen, qs.Ayat)
+}
+
+// SetNormalizer Normalize queries and search a normalized shadow of the
+// text with n, nil to search the text as is
+func (qs *QuranSearch) SetNormalizer(n *Normalizer) {
+	qs.Normalizer = n
+	qs.Shadow = nil
+	if n != nil {
+		qs.Shadow = NewShadow(qs.Quran, n)
+	}
+}
+
+// LoadMorphology Load the morphology file used by the root searches, such
+// as the quranic-corpus-morphology-0.4.txt of the Quranic Arabic Corpus, and
+// align its words with those of the text
+func (qs *QuranSearch) LoadMorphology(filePath string) error {
+	var morphology = &Morphology{}
+	if err := ParseMorphology(filePath, morphology); err != nil {
+		return err
+	}
+	morphology.Align(qs.Quran, qs.Ayat)
+	qs.Morphology = morphology
+	return nil
+}
+
+// SearchRoot Search the ayat holding words of the root, its letters may be
+// separated by spaces; every such word of an aya is highlighted
+func (qs *QuranSearch) SearchRoot(root string, max int) []AyaMatch {
+	rootMethod := RootMethod{Morphology: qs.Morphology, Table: qs.Ayat}
+	return qs.buildAyaResults(rootMethod.Search(qs.Quran, root, -1), max)
+}
+
+// SearchMorphology Search the ayat holding words that match a morphology
+// query such as "lemma:قال AND pos:V", every such word highlighted;
+// ErrNoMorphology before LoadMorphology
+func (qs *QuranSearch) SearchMorphology(q string, max int) ([]AyaMatch, error) {
+	if _, err := ParseMorphQuery(q); err != nil {
+		return nil, err
+	}
+	if qs.Morphology == nil {
+		return nil, ErrNoMorphology
 	}
+	morphology := MorphologyMethod{Morphology: qs.Morphology, Table: qs.Ayat}
+	return qs.buildAyaResults(morphology.Search(qs.Quran, q, -1), max), nil
+}
+
+// SearchWazn Search the ayat holding words on a pattern such as فاعل or
+// "wazn:مفعول", every such word highlighted
+func (qs *QuranSearch) SearchWazn(p string, max int) ([]AyaMatch, error) {
+	if _, err := CompileWazn(p, qs.Uthmani); err != nil {
+		return nil, err
+	}
+	wazn := WaznMethod{Vocalized: qs.Uthmani, Table: qs.Ayat}
+	return qs.buildAyaResults(wazn.Search(qs.Quran, p, -1), max), nil
+}
+
+// SearchWildcard Search a wildcard query such as "ال?ر*" or "{hamza}من",
+// whatever the current method; an error tells where the query is wrong
+func (qs *QuranSearch) SearchWildcard(p string, max int) ([]AyaMatch, error) {
+	if _, err := CompileWildcard(p); err != nil {
+		return nil, err
+	}
+	p = qs.fold(qs.sanitize(p))
+	if len(p) < MIN_PATTERN_LEN {
+		return nil, nil
+	}
+	return qs.buildResults(qs.findWith(METHOD_WILDCARD, p, max), len(p)), nil
+}
+
+// SearchAll Search all the patterns in one pass over the text, each result
+// tagged with its pattern in Nfo.Pattern
+func (qs *QuranSearch) SearchAll(patterns []string, max int) []AyaMatch {
+	text, table := qs.corpus()
+	normalized := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		for _, s := range qs.spellings(qs.fold(p)) {
+			normalized = append(normalized, qs.Normalizer.Normalize(s))
+		}
+	}
+	ahoCorasick := AhoCorasickMethod{Table: table}
+	matches := filterMatchMode(text, ahoCorasick.SearchAll(text, normalized, qs.limit(max)), 0, qs.MatchMode, max)
+	return qs.buildResults(qs.restore(matches, 0), 0)
+}
+
+// FuzzySearch Search the aya substrings within maxDist edits of p, the
+// distance of each result is in Nfo.Distance
+func (qs *QuranSearch) FuzzySearch(p string, maxDist float64, max int) []AyaMatch {
+	text, table := qs.corpus()
+	p = qs.Normalizer.Normalize(qs.spellings(qs.fold(p))[0])
+	fuzzy := FuzzyMethod{MaxDistance: maxDist, Table: table}
+	matches := filterMatchMode(text, fuzzy.Search(text, p, qs.limit(max)), len(p), qs.MatchMode, max)
+	return qs.buildResults(qs.restore(matches, len(p)), len(p))
+}
+
+// ArabiziCandidates Return the Arabic spellings of a romanized query that
+// occur in the text, every partial spelling checked as it grows
+func (qs *QuranSearch) ArabiziCandidates(q string) []string {
+	return expandArabizi(q, func(s string) bool {
+		return qs.Count(s) > 0
+	})
+}
+
+// SearchArabizi Search a romanized query such as "ar-rahim" through all its
+// Arabic spellings found in the text, one result per aya, the ayat holding
+// the most spellings first; the number of spellings is in Score
+func (qs *QuranSearch) SearchArabizi(q string, max int) []AyaMatch {
+	scores := make(map[int]int)
+	spans := make(map[int]SearchMatch)
+	for _, c := range qs.ArabiziCandidates(q) {
+		seen := make(map[int]bool)
+		for _, match := range qs.find(c, -1) {
+			if match.Length == 0 {
+				match.Length = len(c)
+			}
+			if !seen[match.Begin] {
+				seen[match.Begin] = true
+				scores[match.Begin]++
+			}
+			if span, ok := spans[match.Index]; !ok || span.Length < match.Length {
+				spans[match.Index] = match
+			}
+		}
+	}
+
+	matches := make([]SearchMatch, 0, len(spans))
+	for _, match := range spans {
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if scores[a.Begin] != scores[b.Begin] {
+			return scores[a.Begin] > scores[b.Begin]
+		}
+		return a.Index < b.Index
+	})
 
-	return qs.buildResults(matches, len(p))
+	results := qs.buildAyaResults(matches, max)
+	for i := range results {
+		results[i].Score = scores[results[i].Nfo.Begin]
+	}
+	return results
 }
 
 func (qs *QuranSearch) oneLetterSpecialCase(p string) bool {
-	var pchar = int32(p[0])
-	switch pchar {
-	case 'ص':
-		qs.SpecialCases = []SearchMatch{*NewSearchMatch(qs.Quran, 335061, 0)}
-	case 'ق':
-		qs.SpecialCases = []SearchMatch{*NewSearchMatch(qs.Quran, 384642, 0)}
-	case 'ن':
-		qs.SpecialCases = []SearchMatch{*NewSearchMatch(qs.Quran, 421495, 0)}
+	switch p {
+	case "ص":
+		qs.SpecialCases = qs.openings(38)
+	case "ق":
+		qs.SpecialCases = qs.openings(50)
+	case "ن":
+		qs.SpecialCases = qs.openings(68)
 	default:
 		return false
 	}
-	return true
+	return len(qs.SpecialCases) > 0
 }
 
 func (qs *QuranSearch) twoLettersSpecialCase(p string) bool {
 	switch p {
 	case "طه":
-		qs.SpecialCases = []SearchMatch{*NewSearchMatch(qs.Quran, 227524, 0)}
+		qs.SpecialCases = qs.openings(20)
 	case "طس":
-		qs.SpecialCases = []SearchMatch{*NewSearchMatch(qs.Quran, 277440, 0)}
+		qs.SpecialCases = qs.openings(27)
 	case "يس":
-		qs.SpecialCases = []SearchMatch{*NewSearchMatch(qs.Quran, 324531, 0)}
+		qs.SpecialCases = qs.openings(36)
 	case "ص ":
-		qs.SpecialCases = []SearchMatch{*NewSearchMatch(qs.Quran, 335061, 0)}
+		qs.SpecialCases = qs.openings(38)
 	case "حم":
-		qs.SpecialCases = []SearchMatch{
-			*NewSearchMatch(qs.Quran, 346076, 0),
-			*NewSearchMatch(qs.Quran, 353019, 0),
-			*NewSearchMatch(qs.Quran, 357570, 0),
-			*NewSearchMatch(qs.Quran, 362337, 0),
-			*NewSearchMatch(qs.Quran, 367420, 0),
-			*NewSearchMatch(qs.Quran, 369667, 0),
-			*NewSearchMatch(qs.Quran, 372513, 0),
-		}
+		qs.SpecialCases = qs.openings(40, 41, 42, 43, 44, 45, 46)
 	case "ق ":
-		qs.SpecialCases = []SearchMatch{*NewSearchMatch(qs.Quran, 384642, 0)}
+		qs.SpecialCases = qs.openings(50)
 	case "ن ":
-		qs.SpecialCases = []SearchMatch{*NewSearchMatch(qs.Quran, 421495, 0)}
+		qs.SpecialCases = qs.openings(68)
 	default:
 		return false
 	}
-	return true
+	return len(qs.SpecialCases) > 0
+}
+
+// openings Return matches on the isolated letters each surah opens with,
+// after the basmala of its first aya
+func (qs *QuranSearch) openings(surahs ...int) []SearchMatch {
+	matches := make([]SearchMatch, 0, len(surahs))
+	for _, surah := range surahs {
+		n, ok := qs.Ayat.Lookup(surah, 1)
+		if !ok {
+			continue
+		}
+		ao := &qs.Ayat.Ayat[n]
+		if pos := ao.basmalaWords(qs.Quran); pos < len(ao.Words) {
+			matches = append(matches, *qs.Ayat.NewSearchMatch(ao.Words[pos], 0))
+		}
+	}
+	return matches
 }
 
 func (qs *QuranSearch) indexOfSearch(p string, max int, start time.Time) []SearchMatch {
@@ -168,7 +476,7 @@ func (qs *QuranSearch) indexOfSearch(p string, max int, start time.Time) []Searc
 		if index == -1 {
 			break
 		}
-		matches = append(matches, *NewSearchMatch(qs.Quran, index, time.Since(start)))
+		matches = append(matches, *qs.Ayat.NewSearchMatch(index, time.Since(start)))
 		index += len(p)
 	}
 	return matches
@@ -176,17 +484,88 @@ func (qs *QuranSearch) indexOfSearch(p string, max int, start time.Time) []Searc
 
 func (qs *QuranSearch) regexSearch(p string, max int, start time.Time) []SearchMatch {
 	var matches = make([]SearchMatch, 0)
-	re := regexp.MustCompile(p)
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return matches
+	}
 	for _, match := range re.FindAllStringIndex(qs.Quran, max) {
-		matches = append(matches, *NewSearchMatch(qs.Quran, match[0], time.Since(start)))
+		matches = append(matches, *qs.Ayat.NewSearchMatch(match[0], time.Since(start)))
 	}
 	return matches
 }
 
+// wordIndex Return the inverted word index, building it on first use
+func (qs *QuranSearch) wordIndex() *WordIndex {
+	if qs.Shadow != nil {
+		if qs.Shadow.Words == nil {
+			qs.Shadow.Words = NewWordIndex(qs.Shadow.Quran, qs.Shadow.Ayat)
+		}
+		return qs.Shadow.Words
+	}
+	if qs.Words == nil {
+		qs.Words = NewWordIndex(qs.Quran, qs.Ayat)
+	}
+	return qs.Words
+}
+
+// suffixArray Return the suffix array of the text, building it on first use
+func (qs *QuranSearch) suffixArray() *SuffixArray {
+	if qs.Shadow != nil {
+		if qs.Shadow.Suffixes == nil {
+			qs.Shadow.Suffixes = NewSuffixArray(qs.Shadow.Quran)
+		}
+		return qs.Shadow.Suffixes
+	}
+	if qs.Suffixes == nil {
+		qs.Suffixes = NewSuffixArray(qs.Quran)
+	}
+	return qs.Suffixes
+}
+
+// Count Return the number of occurrences of p in the text, all its
+// spellings counted
+func (qs *QuranSearch) Count(p string) int {
+	count := 0
+	for _, s := range qs.spellings(qs.fold(p)) {
+		count += qs.suffixArray().Count(qs.Normalizer.Normalize(s))
+	}
+	return count
+}
+
+// LongestRepeats Return the k longest repeated substrings of the ayat
+func (qs *QuranSearch) LongestRepeats(k int) []Repeat {
+	return qs.suffixArray().LongestRepeats(k)
+}
+
 func (qs *QuranSearch) buildResults(matches []SearchMatch, plen int) []AyaMatch {
 	var results = make([]AyaMatch, 0)
 	for _, match := range matches {
-		results = append(results, *NewAyaMatch(qs.Quran, qs.AyaBegin, match, plen))
+		if match.Index < match.Begin {
+			// a number of the "surah|aya|" prefix, not the aya text
+			continue
+		}
+		mlen := plen
+		if match.Length > 0 {
+			mlen = match.Length
+		}
+		results = append(results, *NewAyaMatch(qs.Quran, qs.AyaBegin, match, mlen))
+	}
+	return results
+}
+
+// buildAyaResults Build one result per aya, holding all its matches, up to
+// max ayat
+func (qs *QuranSearch) buildAyaResults(matches []SearchMatch, max int) []AyaMatch {
+	var results = make([]AyaMatch, 0)
+	for _, match := range matches {
+		if n := len(results) - 1; n >= 0 && results[n].Nfo.Begin == match.Begin {
+			results[n].AddSpan(match.Index, match.Length)
+			continue
+		}
+		if max >= 0 && len(results) >= max {
+			break
+		}
+		results = append(results, *NewAyaMatch(qs.Quran, qs.AyaBegin, match, match.Length))
 	}
 	return results
 }
@@ -0,0 +1,61 @@
+package quransearch
+
+import "testing"
+
+func TestNormalizeRasm(t *testing.T) {
+	n := NewRasmNormalizer()
+	tests := []struct {
+		word, want string
+	}{
+		{"بسم", "ٮسم"},
+		{"ٮسم", "ٮسم"},
+		{"الجنة", "الحٮه"},
+		{"الحٮه", "الحٮه"},
+		{"قال", "ڡال"},
+		{"شيء", "سٮ"},
+		{"نعبد", "ٮعٮد"},
+	}
+	for _, tt := range tests {
+		if got := n.Normalize(tt.word); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSearchRasm(t *testing.T) {
+	qs := newTestSearch(t)
+	qs.SetNormalizer(NewRasmNormalizer())
+	tests := []struct {
+		query, word string
+	}{
+		{"ٮسم", "بسم"},
+		{"الحٮه", "الجنة"},
+	}
+	for _, tt := range tests {
+		matches := qs.find(tt.query, -1)
+		found := 0
+		for _, m := range matches {
+			got := qs.Quran[m.Index : m.Index+m.Length]
+			if qs.Normalizer.Normalize(got) != tt.query {
+				t.Errorf("%s highlighted %q at %d", tt.query, got, m.Index)
+			}
+			if got == tt.word {
+				found++
+			}
+		}
+		if found == 0 {
+			t.Errorf("%s did not find %s", tt.query, tt.word)
+		}
+
+		// the highlight of the aya shown is the word of the original text
+		results := qs.Search(tt.query, 1)
+		if len(results) != 1 {
+			t.Fatalf("%s found %d results with max 1", tt.query, len(results))
+		}
+		r := results[0]
+		aya := r.StrBld.String()
+		if got := aya[r.Indexes[0] : r.Indexes[0]+r.Lens[0]]; got != tt.word {
+			t.Errorf("%s highlighted %q in %d:%d, want %s", tt.query, got, r.Nfo.Surah, r.Nfo.Aya, tt.word)
+		}
+	}
+}
@@ -8,9 +8,14 @@ import (
 func (rx *RegexMethod) Search(text, p string, max int) []SearchMatch {
 	start := time.Now()
 	var matches = make([]SearchMatch, 0)
-	re := regexp.MustCompile(p)
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return matches
+	}
 	for _, match := range re.FindAllStringIndex(text, max) {
-		matches = append(matches, *NewSearchMatch(text, match[0], time.Since(start)))
+		m := newMatch(rx.Table, text, match[0], time.Since(start))
+		m.Length = match[1] - match[0]
+		matches = append(matches, *m)
 	}
 	return matches
 }
@@ -0,0 +1,97 @@
+package quransearch
+
+import (
+	"strings"
+	"unicode"
+)
+
+// compositions the canonical compositions of a letter and a following mark
+var compositions = map[[2]rune]rune{
+	{'ا', 'ٓ'}: 'آ', {'ا', 'ٔ'}: 'أ', {'ا', 'ٕ'}: 'إ', {'و', 'ٔ'}: 'ؤ',
+	{'ي', 'ٔ'}: 'ئ', {'ە', 'ٔ'}: 'ۀ', {'ہ', 'ٔ'}: 'ۂ', {'ے', 'ٔ'}: 'ۓ',
+}
+
+// uthmaniCompositions the compositions valid on the Uthmani text, where an
+// alef followed by a madda is a long vowel and not an alef madda
+var uthmaniCompositions = func() map[[2]rune]rune {
+	m := make(map[[2]rune]rune, len(compositions))
+	for pair, r := range compositions {
+		if pair != [2]rune{'ا', 'ٓ'} {
+			m[pair] = r
+		}
+	}
+	return m
+}()
+
+// invisible Check for the format characters pasted along with Arabic text:
+// soft hyphen, zero width characters, bidi controls and byte order mark
+func invisible(r rune) bool {
+	switch r {
+	case '\u00AD', '\u061C', '\u200B', '\u200C', '\u200D', '\u200E', '\u200F',
+		'\u2060', '\uFEFF':
+		return true
+	}
+	return ('\u202A' <= r && r <= '\u202E') || ('\u2066' <= r && r <= '\u2069')
+}
+
+// SanitizeQuery Clean a pasted query: presentation forms folded to their
+// letters, ligatures expanded, invisible and bidi control characters
+// removed, letters composed with a following hamza or madda and runs of
+// spaces collapsed to one
+func SanitizeQuery(q string) string {
+	return sanitizeWith(q, compositions)
+}
+
+func sanitizeWith(q string, compose map[[2]rune]rune) string {
+	var b strings.Builder
+	b.Grow(len(q))
+	for _, r := range q {
+		if invisible(r) {
+			continue
+		}
+		if s, ok := presentationForms[r]; ok {
+			b.WriteString(s)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return composeMarks(b.String(), compose)
+}
+
+// composeMarks Compose each letter with the first of its marks it has a
+// composition with, keep the other marks in their order and collapse the
+// spaces
+func composeMarks(s string, compose map[[2]rune]rune) string {
+	runes := []rune(s)
+	out := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if unicode.IsSpace(r) {
+			if n := len(out); n == 0 || out[n-1] != ' ' {
+				out = append(out, ' ')
+			}
+			continue
+		}
+		out = append(out, r)
+
+		base := len(out) - 1
+		for i+1 < len(runes) && unicode.Is(unicode.Mn, runes[i+1]) {
+			i++
+			if c, ok := compose[[2]rune{out[base], runes[i]}]; ok {
+				out[base] = c
+				continue
+			}
+			out = append(out, runes[i])
+		}
+	}
+	return string(out)
+}
+
+// sanitize Clean a query for the text searched; the simple text writes no
+// tatweel, so a query stretched with it as in الـلـه is searched without
+func (qs *QuranSearch) sanitize(q string) string {
+	if qs.Uthmani {
+		return sanitizeWith(q, uthmaniCompositions)
+	}
+	return strings.ReplaceAll(sanitizeWith(q, compositions), string(tatweel), "")
+}
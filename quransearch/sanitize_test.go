@@ -0,0 +1,49 @@
+package quransearch
+
+import "testing"
+
+func TestSanitizeQuery(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"ﷲ", "الله"},
+		{"ﻻ ريب", "لا ريب"},
+		{"ﺍﻟﺮﺣﻤﻦ", "الرحمن"},
+		{"الر\u200dحمن", "الرحمن"},
+		{"الر\u200cحمن", "الرحمن"},
+		{"\u200fالرحمن\u200e", "الرحمن"},
+		{"\u202bالرحمن\u202c", "الرحمن"},
+		{"\ufeffالرحمن\u00ad", "الرحمن"},
+		{"\u0627\u0653من", "آمن"},
+		{"\u064a\u0654", "ئ"},
+		{"بسم   الله\tالرحمن", "بسم الله الرحمن"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeQuery(tt.query); got != tt.want {
+			t.Errorf("SanitizeQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchPastedQuery(t *testing.T) {
+	qs := newTestSearch(t)
+	tests := []struct {
+		pasted, query string
+	}{
+		{"ﷲ", "الله"},
+		{"ﻻ ريب فيه", "لا ريب فيه"},
+		{"الر\u200dحمن الر\u200cحيم", "الرحمن الرحيم"},
+		{"\u202bيا أيها الناس\u202c", "يا أيها الناس"},
+		{"الـلـه", "الله"},
+		{"بـسـم ﷲ", "بسم الله"},
+	}
+	for _, tt := range tests {
+		want := len(qs.Search(tt.query, -1))
+		if want == 0 {
+			t.Fatalf("%s is not in the text", tt.query)
+		}
+		if got := len(qs.Search(tt.pasted, -1)); got != want {
+			t.Errorf("%q found %d ayat, %s %d", tt.pasted, got, tt.query, want)
+		}
+	}
+}
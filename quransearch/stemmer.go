@@ -0,0 +1,142 @@
+package quransearch
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// conjunctions proclitics stripped first
+var conjunctions = []string{"و", "ف"}
+
+// radicals the starts of frequent words whose first radical is و or ف, or
+// that start with في, not taken for a conjunction
+var radicals = []string{
+	"فرعون", "فريق", "فريض", "فاحش", "فواحش", "فاسق", "فسق", "فاكه", "فواكه",
+	"فرح", "فتح", "فروج", "فقراء", "فشل", "واحد", "وجوه", "وجد", "وعد", "وصي",
+	"وهب", "ورث", "فتن", "فضل", "فاطر", "فدي", "فوز", "فخور", "فقير", "فسوق",
+	"وجه", "وحد", "ولد", "وراء", "واسع", "واقع", "وكيل", "وعيد", "وسوس",
+	"فيها", "فيهم", "فيهن", "فيما", "فيكم", "فينا",
+}
+
+// articles the article with the prepositions that can come before it
+var articles = []string{"بال", "كال", "فال", "وال", "لل", "ال"}
+
+// prepositions single letter proclitics, only stripped from long words
+var prepositions = []string{"ب", "ك", "ل", "س"}
+
+// enclitics attached pronouns, longest first
+var enclitics = []string{"كما", "هما", "كم", "كن", "هم", "هن", "نا", "ها", "ني", "ه", "ك", "ي"}
+
+const (
+	minStemLen       = 3 // letters a stem keeps
+	minPrepositionIn = 4 // letters left after a single letter proclitic
+	allah            = "الله"
+)
+
+// Stem Strip the proclitics and enclitics of an Arabic word: at most one
+// conjunction, then an article or a single letter preposition, and one
+// attached pronoun, never leaving less than three letters
+func Stem(word string) string {
+	w := stripConjunction(normalizeWord(word))
+	if w == allah || w == "لله" || w == "بالله" {
+		return allah
+	}
+	if s := stripPrefix(w, articles, minStemLen); s != w {
+		return stripSuffix(s)
+	}
+
+	// a single letter preposition is only told apart from a first radical
+	// when enough letters are left once the pronoun is gone
+	return stripPrefix(stripSuffix(w), prepositions, minPrepositionIn)
+}
+
+// stripConjunction Strip a conjunction leaving three letters or more, as
+// in وقال, unless the word starts with one of the radicals, as the و of
+// وجوه and the ف of فرعون do
+func stripConjunction(w string) string {
+	for _, radical := range radicals {
+		if strings.HasPrefix(w, radical) {
+			return w
+		}
+	}
+	return stripPrefix(w, conjunctions, minStemLen)
+}
+
+func stripSuffix(w string) string {
+	for _, suffix := range enclitics {
+		if s, ok := strings.CutSuffix(w, suffix); ok && utf8.RuneCountInString(s) >= minStemLen {
+			return s
+		}
+	}
+	return w
+}
+
+func stripPrefix(w string, prefixes []string, keep int) string {
+	for _, prefix := range prefixes {
+		if s, ok := strings.CutPrefix(w, prefix); ok && utf8.RuneCountInString(s) >= keep {
+			return s
+		}
+	}
+	return w
+}
+
+// Search finds the words, or runs of words, with the same stems as the
+// words of the pattern
+func (sm *StemMethod) Search(text, pattern string, max int) []SearchMatch {
+	start := time.Now()
+	matches := make([]SearchMatch, 0)
+
+	terms := strings.Fields(pattern)
+	if len(terms) == 0 || max == 0 {
+		return matches
+	}
+	stems := make([]string, len(terms))
+	for i, term := range terms {
+		stems[i] = Stem(term)
+	}
+	if sm.Table == nil {
+		sm.Table = NewAyaTable(text)
+	}
+	if sm.Index == nil {
+		sm.Index = NewWordIndex(text, sm.Table)
+	}
+
+	var postings []Posting
+	for _, word := range sm.Index.Words {
+		if Stem(word) == stems[0] {
+			postings = append(postings, sm.Index.Postings[word]...)
+		}
+	}
+	sort.Slice(postings, func(i, j int) bool {
+		return postings[i].Offset < postings[j].Offset
+	})
+
+	for _, p := range postings {
+		ao := &sm.Table.Ayat[sm.Table.Find(p.Offset)]
+		last := p.Pos + len(stems) - 1
+		if last >= len(ao.Words) || !ao.matchStems(text, p.Pos, stems) {
+			continue
+		}
+
+		match := sm.Table.NewSearchMatch(p.Offset, time.Since(start))
+		match.Length = ao.wordEnd(last) - p.Offset
+		matches = append(matches, *match)
+		if max > 0 && len(matches) >= max {
+			break
+		}
+	}
+
+	return matches
+}
+
+// matchStems Check the stems of the aya words from pos on
+func (ao *AyaOffset) matchStems(quran string, pos int, stems []string) bool {
+	for k, stem := range stems {
+		if Stem(ao.word(quran, pos+k)) != stem {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package quransearch
+
+import "testing"
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		word, want string
+	}{
+		{"فرعون", "فرعون"},
+		{"وفرعون", "فرعون"},
+		{"فريقا", "فريقا"},
+		{"واحد", "واحد"},
+		{"فقالوا", "قالوا"},
+		{"والكتاب", "كتاب"},
+		{"بالله", "الله"},
+		{"كتابهم", "كتاب"},
+		{"وقال", "قال"},
+		{"فقال", "قال"},
+		{"وكان", "كان"},
+		{"وعد", "وعد"},
+		{"وجهه", "وجه"},
+		{"فضله", "فضل"},
+		{"فيها", "فيها"},
+		{"فتنة", "فتنة"},
+	}
+	for _, tt := range tests {
+		if got := Stem(tt.word); got != tt.want {
+			t.Errorf("Stem(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSearchStemConjunction(t *testing.T) {
+	qs := newTestSearch(t)
+	sm := StemMethod{Table: qs.Ayat}
+	words := map[string]int{}
+	for _, m := range sm.Search(qs.Quran, "قال", -1) {
+		words[qs.Quran[m.Index:m.Index+m.Length]]++
+	}
+	for _, word := range []string{"قال", "وقال", "فقال"} {
+		if words[word] == 0 {
+			t.Errorf("the stem of قال did not find %s", word)
+		}
+	}
+}
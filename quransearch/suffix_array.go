@@ -0,0 +1,272 @@
+package quransearch
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// NewSuffixArray Build the suffix array and LCP array of the text
+func NewSuffixArray(text string) *SuffixArray {
+	sa := &SuffixArray{Text: text}
+	sa.SA = buildSuffixArray(text)
+	sa.LCP = buildLCP(text, sa.SA)
+	return sa
+}
+
+// buildSuffixArray Sort the suffixes by prefix doubling with radix sorts
+func buildSuffixArray(text string) []int32 {
+	n := len(text)
+	sa := make([]int32, n)
+	if n == 0 {
+		return sa
+	}
+
+	rank := make([]int32, n)
+	tmp := make([]int32, n)
+	for i := 0; i < n; i++ {
+		rank[i] = int32(text[i])
+	}
+	classes := 256
+	count := make([]int32, maxInt(classes, n)+1)
+
+	// initial order by first byte
+	for i := 0; i < n; i++ {
+		count[rank[i]+1]++
+	}
+	for c := 1; c <= classes; c++ {
+		count[c] += count[c-1]
+	}
+	for i := 0; i < n; i++ {
+		sa[count[rank[i]]] = int32(i)
+		count[rank[i]]++
+	}
+
+	for k := 1; ; k <<= 1 {
+		// order by the second half: suffixes without one come first
+		p := 0
+		for i := n - k; i < n; i++ {
+			tmp[p] = int32(i)
+			p++
+		}
+		for _, s := range sa {
+			if int(s) >= k {
+				tmp[p] = s - int32(k)
+				p++
+			}
+		}
+
+		// stable counting sort by the first half
+		for c := 0; c <= classes; c++ {
+			count[c] = 0
+		}
+		for i := 0; i < n; i++ {
+			count[rank[i]+1]++
+		}
+		for c := 1; c <= classes; c++ {
+			count[c] += count[c-1]
+		}
+		for _, s := range tmp {
+			sa[count[rank[s]]] = s
+			count[rank[s]]++
+		}
+
+		// rerank by the (first, second) pairs
+		tmp[sa[0]] = 0
+		for i := 1; i < n; i++ {
+			prev, cur := sa[i-1], sa[i]
+			tmp[cur] = tmp[prev]
+			if rank[prev] != rank[cur] || secondRank(rank, prev, k) != secondRank(rank, cur, k) {
+				tmp[cur]++
+			}
+		}
+		rank, tmp = tmp, rank
+		classes = int(rank[sa[n-1]]) + 1
+		if classes == n {
+			break
+		}
+	}
+
+	return sa
+}
+
+func secondRank(rank []int32, i int32, k int) int32 {
+	if int(i)+k < len(rank) {
+		return rank[int(i)+k]
+	}
+	return -1
+}
+
+// buildLCP Kasai's algorithm, LCP[i] is the common prefix of SA[i-1] and SA[i]
+func buildLCP(text string, sa []int32) []int32 {
+	n := len(text)
+	lcp := make([]int32, n)
+	inv := make([]int32, n)
+	for i, s := range sa {
+		inv[s] = int32(i)
+	}
+
+	h := 0
+	for i := 0; i < n; i++ {
+		if inv[i] == 0 {
+			h = 0
+			continue
+		}
+		j := int(sa[inv[i]-1])
+		for i+h < n && j+h < n && text[i+h] == text[j+h] {
+			h++
+		}
+		lcp[inv[i]] = int32(h)
+		if h > 0 {
+			h--
+		}
+	}
+	return lcp
+}
+
+// Range Return the [lo, hi) range of suffixes starting with the pattern
+func (sa *SuffixArray) Range(pattern string) (int, int) {
+	m := len(pattern)
+	lo := sort.Search(len(sa.SA), func(i int) bool {
+		return sa.prefix(i, m) >= pattern
+	})
+	hi := lo + sort.Search(len(sa.SA)-lo, func(i int) bool {
+		return sa.prefix(lo+i, m) > pattern
+	})
+	return lo, hi
+}
+
+func (sa *SuffixArray) prefix(i, m int) string {
+	s := int(sa.SA[i])
+	return sa.Text[s:minInt(s+m, len(sa.Text))]
+}
+
+// Count Return the number of occurrences of the pattern
+func (sa *SuffixArray) Count(pattern string) int {
+	if pattern == "" {
+		return 0
+	}
+	lo, hi := sa.Range(pattern)
+	return hi - lo
+}
+
+// Locate Return the offsets of every occurrence of the pattern, in text order
+func (sa *SuffixArray) Locate(pattern string) []int {
+	if pattern == "" {
+		return nil
+	}
+	lo, hi := sa.Range(pattern)
+	offsets := make([]int, 0, hi-lo)
+	for _, s := range sa.SA[lo:hi] {
+		offsets = append(offsets, int(s))
+	}
+	sort.Ints(offsets)
+	return offsets
+}
+
+// LongestRepeats Return the k longest runs of whole words of an aya text
+// that occur more than once, longest first
+func (sa *SuffixArray) LongestRepeats(k int) []Repeat {
+	order := make([]int, len(sa.LCP))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return sa.LCP[order[a]] > sa.LCP[order[b]]
+	})
+
+	var repeats []Repeat
+	for _, i := range order {
+		if len(repeats) >= k || sa.LCP[i] == 0 {
+			break
+		}
+		s, t := int(sa.SA[i]), int(sa.SA[i-1])
+		if !startsAyaWord(sa.Text, s) || !startsAyaWord(sa.Text, t) {
+			continue
+		}
+		r := repeatWords(sa.Text, s, t, int(sa.LCP[i]))
+		if r == "" || containsRepeat(repeats, r) {
+			continue
+		}
+		repeats = append(repeats, Repeat{Text: r, Count: sa.countWords(r)})
+	}
+
+	sort.SliceStable(repeats, func(a, b int) bool {
+		return len(repeats[a].Text) > len(repeats[b].Text)
+	})
+	return repeats
+}
+
+// startsAyaWord Check whether a word of an aya text starts at i, not a
+// surah or aya number
+func startsAyaWord(text string, i int) bool {
+	if i == 0 || (text[i-1] != ' ' && text[i-1] != '|') {
+		return false
+	}
+	c := text[i]
+	return !isWordSeparator(c) && (c < '0' || c > '9')
+}
+
+// repeatWords Return the words of the n bytes shared by the texts at s and
+// t, up to the end of the aya and without a trailing part of a word
+func repeatWords(text string, s, t, n int) string {
+	r := text[s : s+n]
+	if end := strings.IndexByte(r, '\n'); end != -1 {
+		r = r[:end]
+	}
+	if !endsWord(text, s+len(r)) || !endsWord(text, t+len(r)) {
+		end := strings.LastIndexByte(r, ' ')
+		if end == -1 {
+			return ""
+		}
+		r = r[:end]
+	}
+	return strings.TrimRight(r, " ")
+}
+
+// countWords Count the occurrences of r as whole words
+func (sa *SuffixArray) countWords(r string) int {
+	count := 0
+	for _, i := range sa.Locate(r) {
+		if startsWord(sa.Text, i) && endsWord(sa.Text, i+len(r)) {
+			count++
+		}
+	}
+	return count
+}
+
+// containsRepeat Check whether the words of r are words of a repeat found
+func containsRepeat(repeats []Repeat, r string) bool {
+	for _, rp := range repeats {
+		if strings.Contains(" "+rp.Text+" ", " "+r+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// Search finds every occurrence of the pattern, overlapping ones included
+func (sm *SuffixArrayMethod) Search(text, pattern string, max int) []SearchMatch {
+	start := time.Now()
+	matches := make([]SearchMatch, 0)
+
+	if sm.Index == nil || sm.Index.Text != text {
+		sm.Index = NewSuffixArray(text)
+	}
+
+	for _, i := range sm.Index.Locate(pattern) {
+		if max >= 0 && len(matches) >= max {
+			break
+		}
+		matches = append(matches, *newMatch(sm.Table, text, i, time.Since(start)))
+	}
+
+	return matches
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
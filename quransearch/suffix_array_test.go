@@ -0,0 +1,89 @@
+package quransearch
+
+import (
+	"strings"
+	"testing"
+)
+
+// equivalencePatterns the patterns every exact method must find as
+// BruteForceMethod does
+var equivalencePatterns = []string{
+	"الله",
+	"من",
+	"ن",
+	"بسم الله الرحمن الرحيم",
+	"في الأرض",
+	"إن الله",
+	"ربك",
+	"ة ",
+	" و",
+	"ظظظ",
+}
+
+// sameMatches Check that the methods agree on the offsets and ayat found
+func sameMatches(t *testing.T, name string, got, want []SearchMatch) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: got %d matches, want %d", name, len(got), len(want))
+		return
+	}
+	for i := range got {
+		g, w := got[i], want[i]
+		if g.Index != w.Index || g.Surah != w.Surah || g.Aya != w.Aya || g.Begin != w.Begin || g.Word != w.Word {
+			t.Errorf("%s: match %d is %d at %d:%d, want %d at %d:%d", name, i,
+				g.Index, g.Surah, g.Aya, w.Index, w.Surah, w.Aya)
+			return
+		}
+	}
+}
+
+// testEquivalence Run the method against BruteForceMethod on the patterns
+func testEquivalence(t *testing.T, method func(*AyaTable) SearchMethod) {
+	qs := newTestSearch(t)
+	brute := &BruteForceMethod{Table: qs.Ayat}
+	for _, p := range equivalencePatterns {
+		for _, max := range []int{-1, 1, 10} {
+			want := brute.Search(qs.Quran, p, max)
+			got := method(qs.Ayat).Search(qs.Quran, p, max)
+			sameMatches(t, p, got, want)
+		}
+	}
+}
+
+func TestSuffixArrayMethodEquivalence(t *testing.T) {
+	sa := NewSuffixArray(newTestSearch(t).Quran)
+	testEquivalence(t, func(table *AyaTable) SearchMethod {
+		return &SuffixArrayMethod{Index: sa, Table: table}
+	})
+}
+
+func TestSuffixArrayCount(t *testing.T) {
+	qs := newTestSearch(t)
+	sa := NewSuffixArray(qs.Quran)
+	for _, p := range equivalencePatterns {
+		want := len((&BruteForceMethod{}).Search(qs.Quran, p, -1))
+		if got := sa.Count(p); got != want {
+			t.Errorf("Count(%q) = %d, want %d", p, got, want)
+		}
+	}
+}
+
+func TestLongestRepeats(t *testing.T) {
+	qs := newTestSearch(t)
+	sa := NewSuffixArray(qs.Quran)
+	repeats := sa.LongestRepeats(20)
+	if len(repeats) != 20 {
+		t.Fatalf("got %d repeats, want 20", len(repeats))
+	}
+	for _, r := range repeats {
+		if strings.ContainsAny(r.Text, "|\n0123456789") {
+			t.Errorf("repeat %q crosses an aya line", r.Text)
+		}
+		if r.Count < 2 || r.Count != sa.countWords(r.Text) {
+			t.Errorf("repeat %q counted %d times", r.Text, r.Count)
+		}
+		if strings.HasPrefix(r.Text, " ") || strings.HasSuffix(r.Text, " ") {
+			t.Errorf("repeat %q is not trimmed", r.Text)
+		}
+	}
+}
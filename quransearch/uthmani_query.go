@@ -0,0 +1,80 @@
+package quransearch
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	hamzaForms = "[ءأإئؤ]"
+	silentMark = "۟" // small high rounded zero, on a letter not read
+)
+
+// IsUthmaniQuery Check whether the query holds diacritics or Uthmani marks
+func IsUthmaniQuery(p string) bool {
+	return strings.ContainsAny(p, quranicMarks)
+}
+
+// BuildSimpleRegEx Build a regex pattern matching the simple script text
+// of a query typed in Uthmani spelling, the reverse of BuildUthmaniRegEx
+func BuildSimpleRegEx(p string) string {
+	var b strings.Builder
+	cs := clusters(p, 0)
+
+	for i := 0; i < len(cs); i++ {
+		c := cs[i]
+		nextAlef := i+1 < len(cs) && cs[i+1].base == 'ا' && cs[i+1].marks == 0 &&
+			!strings.HasPrefix(p[cs[i+1].end:], silentMark)
+		dagger := c.marks&vowelMarks['ٰ'] != 0
+		hamza := strings.ContainsRune("ءأإئؤ", c.base) ||
+			(c.base == 'ا' || c.base == tatweel) && c.marks&(vowelMarks['ٔ']|vowelMarks['ٕ']) != 0
+
+		switch {
+		case hamza && c.base == tatweel && dagger:
+			// ٱلْـَٰٔنَ is written الآن
+			b.WriteString("(آ|" + hamzaForms + "ا?)")
+			dagger = false
+		case hamza && nextAlef:
+			// ءَامَنُوا۟ is written آمنوا and رَءَا رأى
+			b.WriteString("(" + hamzaForms + "ا|آ|أى)")
+			i++
+		case hamza:
+			// the seat of the hamza is often another one
+			b.WriteString(hamzaForms)
+		case c.base == tatweel:
+		case c.base == 'و' && dagger:
+			// الصلوٰة is written الصلاة, ٱلسَّمَٰوَٰتِ السماوات
+			b.WriteString("(وا?|ا)")
+			dagger = false
+		case c.base == 'ى' && dagger:
+			b.WriteString("[ىا]")
+			dagger = false
+		case c.base == 'ى':
+			b.WriteString("[ىي]")
+		case c.base == 'ا' && c.marks&vowelMarks['ٓ'] != 0:
+			b.WriteString("[اآ]")
+		case c.base == 'ل' && c.marks&vowelMarks['ّ'] != 0 && i > 0 && cs[i-1].base == 'ا' &&
+			(strings.HasPrefix(p[cs[i-1].begin:], "ٱ") || i == 1 || cs[i-2].base == ' '):
+			// the lam of the article under a shadda, as in ٱلَّيْلِ
+			b.WriteString("لل?")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c.base)))
+			if strings.HasPrefix(p[c.end:], silentMark) {
+				// أُو۟لُوا۟ and يَتْلُوا۟ are written أولو and يتلو
+				b.WriteString("?")
+			}
+		}
+
+		if dagger {
+			// the long alef, written or not, sometimes a word of its own
+			b.WriteString("(ا ?)?")
+		}
+		if c.marks&vowelMarks['ۥ'] != 0 {
+			b.WriteString("و?")
+		}
+		if c.marks&vowelMarks['ۦ'] != 0 {
+			b.WriteString("ي?")
+		}
+	}
+	return b.String()
+}
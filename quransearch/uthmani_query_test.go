@@ -0,0 +1,40 @@
+package quransearch
+
+import "testing"
+
+func TestSearchUthmaniQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		ayat  bool
+	}{
+		{"ءَامَنُوا۟", true},
+		{"ٱلصَّلَوٰةَ", true},
+		{"مَٰلِكِ يَوْمِ ٱلدِّينِ", true},
+		{"َ", false},
+		{"ـ", false},
+		{"ً ً", false},
+	}
+	for _, normalized := range []bool{false, true} {
+		qs := newTestSearch(t)
+		if normalized {
+			qs.SetNormalizer(NewNormalizer())
+		}
+		for _, mode := range []int{MATCH_ANYWHERE, MATCH_INFIX} {
+			qs.MatchMode = mode
+			for _, tt := range tests {
+				results := qs.Search(tt.query, -1)
+				if tt.ayat && mode == MATCH_ANYWHERE && len(results) == 0 {
+					t.Errorf("%q found nothing, normalized %v", tt.query, normalized)
+				}
+				if !tt.ayat && len(results) != 0 {
+					t.Errorf("%q found %d results, normalized %v", tt.query, len(results), normalized)
+				}
+				for _, r := range results {
+					if r.Nfo.Index < r.Nfo.Begin {
+						t.Errorf("%q matched at %d, before the aya text", tt.query, r.Nfo.Index)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package quransearch
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// letterVariants the Persian and Urdu letters and the Eastern Arabic-Indic
+// digits folded to the code points of the text; the Persian yeh is left to
+// LetterVariantSpellings
+var letterVariants = strings.NewReplacer(
+	"ې", "ي", "ے", "ي", "ۓ", "ئ",
+	"ک", "ك", "ڪ", "ك",
+	"ہ", "ه", "ھ", "ه", "ە", "ه", "ۂ", "ه", "ۀ", "ه",
+	"ۃ", "ة",
+	"۰", "0", "۱", "1", "۲", "2", "۳", "3", "۴", "4",
+	"۵", "5", "۶", "6", "۷", "7", "۸", "8", "۹", "9",
+	"٠", "0", "١", "1", "٢", "2", "٣", "3", "٤", "4",
+	"٥", "5", "٦", "6", "٧", "7", "٨", "8", "٩", "9",
+)
+
+// maxFinalYeh the words ending with a Persian yeh spelled both ways, the
+// ones after them taken for an alef maqsura
+const maxFinalYeh = 3
+
+// FoldLetterVariants Replace the Persian and Urdu forms of the letters by the
+// Arabic ones and the Eastern Arabic-Indic digits by 0-9; a Persian yeh
+// ending a word becomes an alef maqsura, the first of LetterVariantSpellings
+func FoldLetterVariants(q string) string {
+	return LetterVariantSpellings(q)[0]
+}
+
+// LetterVariantSpellings Return the spellings of a query with its letter
+// variants folded. The Persian yeh is undotted at the end of a word, so
+// there it stands for the alef maqsura of موسى as well as for the ya of في
+// and الذي, and each word it ends is spelled both ways
+func LetterVariantSpellings(q string) []string {
+	runes := []rune(letterVariants.Replace(q))
+	var finals []int
+	for i, r := range runes {
+		if r != 'ی' {
+			continue
+		}
+		runes[i] = 'ي'
+		j := i + 1
+		for j < len(runes) && unicode.Is(unicode.Mn, runes[j]) {
+			j++
+		}
+		if j == len(runes) || !unicode.IsLetter(runes[j]) {
+			runes[i] = 'ى'
+			finals = append(finals, i)
+		}
+	}
+
+	finals = finals[:minInt(len(finals), maxFinalYeh)]
+	spellings := make([]string, 0, 1<<len(finals))
+	for mask := 0; mask < 1<<len(finals); mask++ {
+		for k, i := range finals {
+			runes[i] = 'ى'
+			if mask&(1<<k) != 0 {
+				runes[i] = 'ي'
+			}
+		}
+		spellings = append(spellings, string(runes))
+	}
+	return spellings
+}
+
+// fold Fold the letter variants of a query when FoldVariants is set, the
+// Persian yeh kept for spellings
+func (qs *QuranSearch) fold(q string) string {
+	if !qs.FoldVariants {
+		return q
+	}
+	return letterVariants.Replace(q)
+}
+
+// spellings Return the spellings of a query searched, one unless
+// FoldVariants is set and a word ends with a Persian yeh
+func (qs *QuranSearch) spellings(q string) []string {
+	if !qs.FoldVariants {
+		return []string{q}
+	}
+	return LetterVariantSpellings(q)
+}
+
+// findSpellings Run find over each spelling of p, the matches of a query
+// spelled more than one way merged in text order
+func (qs *QuranSearch) findSpellings(p string, max int, find func(p string, max int) []SearchMatch) []SearchMatch {
+	spellings := qs.spellings(p)
+	if len(spellings) == 1 {
+		return find(spellings[0], max)
+	}
+	var matches []SearchMatch
+	for _, s := range spellings {
+		matches = append(matches, find(s, -1)...)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Index < matches[j].Index
+	})
+	if max >= 0 && len(matches) > max {
+		matches = matches[:max]
+	}
+	return matches
+}
@@ -0,0 +1,88 @@
+package quransearch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFoldLetterVariants(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"موسی", "موسى"},
+		{"عیسی ابن مریم", "عيسى ابن مريم"},
+		{"کتاب", "كتاب"},
+		{"رحمۃ", "رحمة"},
+		{"ہدی", "هدى"},
+		{"۲۵۵", "255"},
+		{"٢٥٥", "255"},
+		{"۰۱۲۳۴۵۶۷۸۹", "0123456789"},
+	}
+	for _, tt := range tests {
+		if got := FoldLetterVariants(tt.query); got != tt.want {
+			t.Errorf("FoldLetterVariants(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestLetterVariantSpellings(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"کتاب", []string{"كتاب"}},
+		{"مریم", []string{"مريم"}},
+		{"الذی", []string{"الذى", "الذي"}},
+		{"فی الأرض", []string{"فى الأرض", "في الأرض"}},
+		{"الذی فی", []string{"الذى فى", "الذي فى", "الذى في", "الذي في"}},
+	}
+	for _, tt := range tests {
+		if got := LetterVariantSpellings(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LetterVariantSpellings(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchLetterVariants(t *testing.T) {
+	qs := newTestSearch(t)
+	tests := []struct {
+		query, arabic string
+	}{
+		{"موسی", "موسى"},
+		{"الذی", "الذي"},
+		{"فی الأرض", "في الأرض"},
+		{"عیسی ابن مریم", "عيسى ابن مريم"},
+	}
+	for _, tt := range tests {
+		got, want := qs.Search(tt.query, -1), qs.Search(tt.arabic, -1)
+		if len(got) != len(want) || len(got) == 0 {
+			t.Errorf("%s found %d ayat, %s %d", tt.query, len(got), tt.arabic, len(want))
+			continue
+		}
+		for i := range got {
+			if got[i].Nfo.Index != want[i].Nfo.Index {
+				t.Errorf("%s found %d:%d where %s finds %d:%d", tt.query, got[i].Nfo.Surah, got[i].Nfo.Aya,
+					tt.arabic, want[i].Nfo.Surah, want[i].Nfo.Aya)
+				break
+			}
+		}
+		if got, want := qs.Count(tt.query), qs.Count(tt.arabic); got != want {
+			t.Errorf("%s counted %d times, %s %d", tt.query, got, tt.arabic, want)
+		}
+	}
+	if got := len(qs.Search("الذی", 10)); got != 10 {
+		t.Errorf("الذی found %d ayat with max 10", got)
+	}
+
+	qs.FoldVariants = false
+	if got := qs.Search("الذی", -1); len(got) != 0 {
+		t.Errorf("الذی found %d ayat without folding", len(got))
+	}
+	qs.FoldVariants = true
+
+	for _, q := range []string{"۲۵۵", "255"} {
+		if results := qs.Search(q, -1); len(results) != 0 {
+			t.Errorf("%s matched the aya numbers %d times", q, len(results))
+		}
+	}
+}
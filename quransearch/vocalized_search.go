@@ -0,0 +1,131 @@
+package quransearch
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// vowelMarks the diacritics that bind when typed in a query, the other
+// Quranic marks (pause, silence and iqlab signs) are never compared
+var vowelMarks = map[rune]uint32{
+	'ً': 1 << 0,  // fathatan
+	'ٌ': 1 << 1,  // dammatan
+	'ٍ': 1 << 2,  // kasratan
+	'َ': 1 << 3,  // fatha
+	'ُ': 1 << 4,  // damma
+	'ِ': 1 << 5,  // kasra
+	'ّ': 1 << 6,  // shadda
+	'ْ': 1 << 7,  // sukun
+	'ۡ': 1 << 7,  // Uthmani sukun
+	'ٰ': 1 << 8,  // dagger alef
+	'ٓ': 1 << 9,  // maddah
+	'ٔ': 1 << 10, // hamza above
+	'ٕ': 1 << 11, // hamza below
+	'ۥ': 1 << 12, // small waw
+	'ۦ': 1 << 13, // small yeh
+	'ٖ': 1 << 14, // subscript alef
+	'ٗ': 1 << 15, // inverted damma
+}
+
+// cluster a base letter with the vowel marks written on it
+type cluster struct {
+	base       rune
+	marks      uint32
+	begin, end int // bytes of the letter and all its marks
+}
+
+// Search finds the pattern in a vocalized text: the marks typed on a
+// letter of the pattern must all be on the letter of the text, which may
+// carry more, so a fatha typed alone matches a fatha under a shadda and a
+// bare letter matches any vocalization
+func (vm *VocalizedMethod) Search(text, pattern string, max int) []SearchMatch {
+	start := time.Now()
+	matches := make([]SearchMatch, 0)
+
+	p := spellDaggers(clusters(pattern, 0))
+	if len(p) == 0 || max == 0 {
+		return matches
+	}
+	if vm.Table == nil {
+		vm.Table = NewAyaTable(text)
+	}
+
+	for _, ao := range vm.Table.Ayat {
+		t := spellDaggers(clusters(text[ao.Begin:ao.End], ao.Begin))
+		for k := 0; k+len(p) <= len(t); k++ {
+			if !matchClusters(p, t[k:k+len(p)]) {
+				continue
+			}
+			match := newMatch(vm.Table, text, t[k].begin, time.Since(start))
+			match.Length = t[k+len(p)-1].end - t[k].begin
+			matches = append(matches, *match)
+			if max > 0 && len(matches) >= max {
+				return matches
+			}
+		}
+	}
+
+	return matches
+}
+
+func matchClusters(p, t []cluster) bool {
+	for i := range p {
+		if p[i].base != t[i].base {
+			return false
+		}
+		if p[i].marks&^t[i].marks != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// spellDaggers Write the dagger alef of each cluster as a bare alef cluster
+// following it, so مَٰلِكِ and مَالِك compare equal; the alef is empty and
+// closes the letter cluster
+func spellDaggers(cs []cluster) []cluster {
+	spelled := make([]cluster, 0, len(cs))
+	for _, c := range cs {
+		if c.marks&vowelMarks['ٰ'] == 0 {
+			spelled = append(spelled, c)
+			continue
+		}
+		c.marks &^= vowelMarks['ٰ']
+		spelled = append(spelled, c, cluster{base: 'ا', begin: c.end, end: c.end})
+	}
+	return spelled
+}
+
+// clusters Split s into letter clusters, offsets shifted by base. Marks that
+// never bind are skipped, with the space they leave behind when they stand
+// alone as pause marks.
+func clusters(s string, base int) []cluster {
+	var cs []cluster
+	for i, r := range s {
+		if bit, ok := vowelMarks[r]; ok {
+			if n := len(cs) - 1; n >= 0 && cs[n].base != ' ' {
+				cs[n].marks |= bit
+				cs[n].end = i + utf8.RuneLen(r) + base
+			}
+			continue
+		}
+		if r != 'ٱ' && r != tatweel && strings.ContainsRune(quranicMarks, r) {
+			continue
+		}
+
+		c := cluster{base: r, begin: i + base, end: i + utf8.RuneLen(r) + base}
+		switch r {
+		case 'ٱ':
+			c.base = 'ا'
+		case 'آ':
+			c.base, c.marks = 'ا', vowelMarks['ٓ']
+		case ' ':
+			if n := len(cs) - 1; n < 0 || cs[n].base == ' ' {
+				continue
+			}
+		}
+		cs = append(cs, c)
+	}
+	return cs
+}
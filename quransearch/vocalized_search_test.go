@@ -0,0 +1,61 @@
+package quransearch
+
+import "testing"
+
+func TestVocalizedDaggerAlef(t *testing.T) {
+	qs := newTestXMLSearch(t)
+	qs.CurrentMethod = METHOD_VOCALIZED
+	tests := []struct {
+		query string
+		found bool
+	}{
+		{"مَالِكِ", true},
+		{"مَٰلِكِ", true},
+		{"مالك", true},
+		{"ٱلْعَالَمِينَ", true},
+		{"مُالِكِ", false},
+	}
+	for _, tt := range tests {
+		results := qs.Search(tt.query, -1)
+		if tt.found && len(results) == 0 {
+			t.Errorf("%q found nothing", tt.query)
+		}
+		if !tt.found && len(results) != 0 {
+			t.Errorf("%q found %d ayat", tt.query, len(results))
+		}
+	}
+}
+
+func TestSpellDaggers(t *testing.T) {
+	full := spellDaggers(clusters("مَالِكِ", 0))
+	dagger := spellDaggers(clusters("مَٰلِكِ", 0))
+	if !matchClusters(full, dagger) || !matchClusters(dagger, full) {
+		t.Errorf("مَالِكِ and مَٰلِكِ differ: %v %v", full, dagger)
+	}
+	if c := dagger[1]; c.base != 'ا' || c.begin != c.end || c.begin != dagger[0].end {
+		t.Errorf("dagger spelled as %+v after %+v", c, dagger[0])
+	}
+}
+
+func TestVocalizedMarkSubset(t *testing.T) {
+	qs := newTestXMLSearch(t)
+	qs.CurrentMethod = METHOD_VOCALIZED
+
+	// مَلِكِ of 114:2 and not the long vowel of مَٰلِكِ in 1:4
+	found := map[[2]int]bool{}
+	for _, r := range qs.Search("مَلِكِ", -1) {
+		found[[2]int{r.Nfo.Surah, r.Nfo.Aya}] = true
+	}
+	if !found[[2]int{114, 2}] || found[[2]int{1, 4}] {
+		t.Errorf("مَلِكِ found 114:2 %v and 1:4 %v", found[[2]int{114, 2}], found[[2]int{1, 4}])
+	}
+
+	// a fatha typed alone matches a fatha under a shadda
+	want := len(qs.Search("ٱلرَّحْمَٰنِ", -1))
+	if got := len(qs.Search("ٱلرَحْمَٰنِ", -1)); got != want || want == 0 {
+		t.Errorf("ٱلرَحْمَٰنِ found %d ayat, ٱلرَّحْمَٰنِ %d", got, want)
+	}
+	if got := len(qs.Search("ٱلرُحْمَٰنِ", -1)); got != 0 {
+		t.Errorf("ٱلرُحْمَٰنِ found %d ayat", got)
+	}
+}
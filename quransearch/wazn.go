@@ -0,0 +1,137 @@
+package quransearch
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	WAZN_PREFIX = "wazn:"
+	shadda      = 'ّ'
+)
+
+// radical a consonant in place of a radical of the pattern: the long vowels
+// ا و ي ى, the ة and the tatweel excluded
+const radical = "([ء-ئبت-ؿف-ه])"
+
+// weakRadical a radical that can also be a consonantal و or ي, at the start
+// of a word or under a shadda
+const weakRadical = "([ء-ئبت-ؿف-هوي])"
+
+// waznProclitics the conjunction and article or preposition a word may start
+// with ahead of its pattern
+const waznProclitics = "(?:[وف]?(?:بال|كال|ال|لل|[بكل])?)"
+
+// sunShadda the shadda of the letter the article assimilates to, not part
+// of the pattern of the word
+var sunShadda = regexp.MustCompile(`^[وف]?[بك]?(?:ال|لل)[ء-ي]ّ`)
+
+// CompileWazn Compile a pattern such as مفعول or استفعل into a matcher of
+// whole words, proclitics allowed: ف ع and ل stand for the radicals, a
+// second ل for the fourth one, the other letters are literal and the vowel
+// marks ignored; with shaddas set, a shadda of the pattern must be written
+// on the word. A radical is never a long vowel, a weak radical is written
+// in the pattern as in قال on فال
+func CompileWazn(pattern string, shaddas bool) (*regexp.Regexp, error) {
+	var b strings.Builder
+	radicals := 0
+	runes := []rune(strings.TrimPrefix(pattern, WAZN_PREFIX))
+	for i, r := range runes {
+		switch {
+		case r == 'ف' || r == 'ع' || r == 'ل':
+			if b.Len() == 0 || (i+1 < len(runes) && runes[i+1] == shadda) {
+				b.WriteString(weakRadical)
+			} else {
+				b.WriteString(radical)
+			}
+			radicals++
+		case r == shadda:
+			if shaddas {
+				b.WriteRune(shadda)
+			}
+		case r == 'ٱ':
+			b.WriteRune('ا')
+		case r == tatweel || strings.ContainsRune(quranicMarks, r):
+		case r >= 'ء' && r <= 'ي':
+			b.WriteRune(r)
+		default:
+			return nil, fmt.Errorf("CompileWazn: %q is not an Arabic letter", r)
+		}
+	}
+	if radicals < 3 {
+		return nil, fmt.Errorf("CompileWazn: %q holds %d radicals, want at least 3", pattern, radicals)
+	}
+	return regexp.MustCompile("^" + waznProclitics + "(" + b.String() + ")$"), nil
+}
+
+// waznWord Return the letters of a word with its long vowels spelled out,
+// the dagger alef as ا where the simple script writes it and shaddas kept,
+// with the offset in word of each byte
+func waznWord(word string) (string, []int) {
+	var b strings.Builder
+	offsets := make([]int, 0, len(word)+1)
+	for i, r := range word {
+		switch {
+		case r == daggerAlef && daggerDropped(word, i):
+			continue
+		case r == 'ٱ' || r == daggerAlef:
+			r = 'ا'
+		case r == shadda:
+		case r == tatweel || strings.ContainsRune(quranicMarks, r):
+			continue
+		}
+		b.WriteRune(r)
+		for k := utf8.RuneLen(r); k > 0; k-- {
+			offsets = append(offsets, i)
+		}
+	}
+
+	w := b.String()
+	if m := sunShadda.FindStringIndex(w); m != nil {
+		cut := m[1] - utf8.RuneLen(shadda)
+		w = w[:cut] + w[m[1]:]
+		offsets = append(offsets[:cut], offsets[m[1]:]...)
+	}
+	return w, append(offsets, len(word))
+}
+
+// Search finds every word of the text on the pattern, highlighting the
+// word without its proclitics
+func (wm *WaznMethod) Search(text, pattern string, max int) []SearchMatch {
+	start := time.Now()
+	matches := make([]SearchMatch, 0)
+
+	re, err := CompileWazn(pattern, wm.Vocalized)
+	if err != nil || max == 0 {
+		return matches
+	}
+	if wm.Table == nil {
+		wm.Table = NewAyaTable(text)
+	}
+
+	for n := range wm.Table.Ayat {
+		ao := &wm.Table.Ayat[n]
+		for pos := ao.basmalaWords(text); pos < len(ao.Words); pos++ {
+			word, offsets := waznWord(ao.word(text, pos))
+			m := re.FindStringSubmatchIndex(word)
+			if m == nil {
+				continue
+			}
+			match := wm.Table.NewSearchMatch(ao.Words[pos]+offsets[m[2]], time.Since(start))
+			match.Length = offsets[m[3]] - offsets[m[2]]
+			if m[3] == len(word) {
+				// the marks after the last letter belong to the word
+				match.Length = ao.wordEnd(pos) - match.Index
+			}
+			matches = append(matches, *match)
+			if max > 0 && len(matches) >= max {
+				return matches
+			}
+		}
+	}
+
+	return matches
+}
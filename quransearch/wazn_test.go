@@ -0,0 +1,68 @@
+package quransearch
+
+import "testing"
+
+func TestCompileWaznErrors(t *testing.T) {
+	for _, p := range []string{"فع", "wazn:مفع", "فعxل"} {
+		if _, err := CompileWazn(p, false); err == nil {
+			t.Errorf("CompileWazn(%q) compiled", p)
+		}
+	}
+}
+
+func TestWaznWords(t *testing.T) {
+	tests := []struct {
+		pattern string
+		shaddas bool
+		word    string
+		match   bool
+	}{
+		{"مفعول", false, "معروف", true},
+		{"مفعول", false, "بالمعروف", true},
+		{"مفعول", false, "ماتوا", false},
+		{"مفعول", false, "ماروت", false},
+		{"مفعول", false, "موفون", false},
+		{"فاعل", false, "كافر", true},
+		{"فاعل", false, "واحد", true},
+		{"فاعل", true, "خَٰلِقُ", true},
+		{"فاعل", true, "ذَٰلِكَ", false},
+		{"wazn:استفعل", false, "استغفر", true},
+		{"فعّال", true, "كَذَّابٌ", true},
+		{"فعّال", true, "كِتَابٌ", false},
+		{"فعيل", false, "الرحيم", true},
+	}
+	for _, tt := range tests {
+		re, err := CompileWazn(tt.pattern, tt.shaddas)
+		if err != nil {
+			t.Fatalf("CompileWazn(%q): %v", tt.pattern, err)
+		}
+		word, _ := waznWord(tt.word)
+		if got := re.MatchString(word); got != tt.match {
+			t.Errorf("%s on %s = %v, want %v", tt.pattern, tt.word, got, tt.match)
+		}
+	}
+}
+
+func TestSearchWazn(t *testing.T) {
+	qs := newTestSearch(t)
+	results, err := qs.SearchWazn("wazn:مفعول", -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) == 0 {
+		t.Fatal("no aya holds a word on مفعول")
+	}
+	re, _ := CompileWazn("مفعول", false)
+	for _, r := range results {
+		for i, index := range r.Indexes {
+			word := r.StrBld.String()[index : index+r.Lens[i]]
+			if !re.MatchString(word) {
+				t.Errorf("%d:%d highlights %q", r.Nfo.Surah, r.Nfo.Aya, word)
+			}
+		}
+	}
+
+	if _, err := qs.SearchWazn("فع", -1); err == nil {
+		t.Error("SearchWazn accepted a pattern of two radicals")
+	}
+}
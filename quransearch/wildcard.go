@@ -0,0 +1,84 @@
+package quransearch
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// arabicLetter any Arabic letter, tatweel excluded
+const arabicLetter = "[ء-ؿف-يٱ]"
+
+// letterClasses the letter classes a wildcard query names in braces
+var letterClasses = map[string]string{
+	"letter": arabicLetter,
+	"hamza":  "[ءأإؤئ]",
+	"alef":   "[اأإآٱى]",
+	"ya":     "[يىئ]",
+	"waw":    "[وؤ]",
+	"ta":     "[تة]",
+	"ha":     "[هة]",
+}
+
+// CompileWildcard Compile a wildcard query into a regex: ? or ؟ stand for
+// one Arabic letter, * for any run of letters inside a word and a class
+// in braces, such as {hamza}, for one of its letters
+func CompileWildcard(p string) (*regexp.Regexp, error) {
+	if p == "" {
+		return nil, fmt.Errorf("CompileWildcard: empty query")
+	}
+	var b strings.Builder
+	runes := []rune(p)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '?', '؟':
+			b.WriteString(arabicLetter)
+		case '*':
+			b.WriteString(arabicLetter + "*")
+		case '{':
+			end := i + 1
+			for end < len(runes) && runes[end] != '}' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("CompileWildcard: unclosed { at %d", i)
+			}
+			name := string(runes[i+1 : end])
+			class, ok := letterClasses[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("CompileWildcard: unknown class {%s} at %d", name, i)
+			}
+			b.WriteString(class)
+			i = end
+		case '}':
+			return nil, fmt.Errorf("CompileWildcard: unopened } at %d", i)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return regexp.Compile(b.String())
+}
+
+// Search finds the matches of a wildcard query, none when it does not compile
+func (wm *WildcardMethod) Search(text, pattern string, max int) []SearchMatch {
+	start := time.Now()
+	matches := make([]SearchMatch, 0)
+	re, err := CompileWildcard(pattern)
+	if err != nil {
+		return matches
+	}
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		if max >= 0 && len(matches) >= max {
+			break
+		}
+		if loc[0] == loc[1] {
+			// a lone * matches nothing everywhere
+			continue
+		}
+		match := newMatch(wm.Table, text, loc[0], time.Since(start))
+		match.Length = loc[1] - loc[0]
+		matches = append(matches, *match)
+	}
+	return matches
+}
@@ -0,0 +1,57 @@
+package quransearch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompileWildcardErrors(t *testing.T) {
+	tests := []struct {
+		query, err string
+	}{
+		{"", "empty query"},
+		{"ال{hamza", "unclosed { at 2"},
+		{"{x}ن", "unknown class {x} at 0"},
+		{"من}", "unopened } at 2"},
+	}
+	for _, tt := range tests {
+		_, err := CompileWildcard(tt.query)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("CompileWildcard(%q) error %v, want %q", tt.query, err, tt.err)
+		}
+	}
+}
+
+func TestSearchWildcard(t *testing.T) {
+	qs := newTestSearch(t)
+	tests := []struct {
+		query string
+		words []string // words the matches must hold
+	}{
+		{"كت?ب", []string{"كتاب", "كتتب"}},
+		{"{hamza}من", []string{"أمن", "ؤمن"}},
+		{"الصا*ين", []string{"الصابرين", "الصادقين"}},
+	}
+	for _, tt := range tests {
+		re, _ := CompileWildcard(tt.query)
+		results, err := qs.SearchWildcard(tt.query, -1)
+		if err != nil || len(results) == 0 {
+			t.Errorf("SearchWildcard(%q) = %d results, %v", tt.query, len(results), err)
+			continue
+		}
+
+		found := make(map[string]bool)
+		for _, r := range results {
+			text := qs.Quran[r.Nfo.Index : r.Nfo.Index+r.Nfo.Length]
+			if !re.MatchString(text) {
+				t.Errorf("%q: highlighted %q does not match", tt.query, text)
+			}
+			found[text] = true
+		}
+		for _, w := range tt.words {
+			if !found[w] {
+				t.Errorf("%q: no match of %q", tt.query, w)
+			}
+		}
+	}
+}
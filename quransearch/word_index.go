@@ -0,0 +1,165 @@
+package quransearch
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const wordPrefixMark = "*"
+
+// NewWordIndex Build the inverted word index of the quran text
+func NewWordIndex(quran string, table *AyaTable) *WordIndex {
+	wi := &WordIndex{Postings: make(map[string][]Posting)}
+
+	for _, ao := range table.Ayat {
+		for pos, start := range ao.Words {
+			word := normalizeWord(ao.word(quran, pos))
+			if word == "" {
+				continue
+			}
+			wi.Postings[word] = append(wi.Postings[word], Posting{
+				Surah:  ao.Surah,
+				Aya:    ao.Aya,
+				Pos:    pos,
+				Offset: start,
+			})
+		}
+	}
+
+	wi.Words = make([]string, 0, len(wi.Postings))
+	for word := range wi.Postings {
+		wi.Words = append(wi.Words, word)
+	}
+	sort.Strings(wi.Words)
+
+	return wi
+}
+
+// Lookup Return the postings of the whole word w
+func (wi *WordIndex) Lookup(w string) []Posting {
+	return wi.Postings[normalizeWord(w)]
+}
+
+// Prefix Return the postings of every word starting with prefix, in text order
+func (wi *WordIndex) Prefix(prefix string) []Posting {
+	prefix = normalizeWord(prefix)
+	var postings []Posting
+	for n := sort.SearchStrings(wi.Words, prefix); n < len(wi.Words); n++ {
+		if !strings.HasPrefix(wi.Words[n], prefix) {
+			break
+		}
+		postings = append(postings, wi.Postings[wi.Words[n]]...)
+	}
+	sort.Slice(postings, func(i, j int) bool {
+		return postings[i].Offset < postings[j].Offset
+	})
+	return postings
+}
+
+// Search finds the words of the pattern as consecutive whole words; a term
+// ending with "*" matches any word starting with it
+func (wm *WordIndexMethod) Search(text, pattern string, max int) []SearchMatch {
+	start := time.Now()
+	matches := make([]SearchMatch, 0)
+
+	terms := strings.Fields(pattern)
+	if len(terms) == 0 || max == 0 {
+		return matches
+	}
+	if wm.Table == nil {
+		wm.Table = NewAyaTable(text)
+	}
+	if wm.Index == nil {
+		wm.Index = NewWordIndex(text, wm.Table)
+	}
+
+	for _, p := range wm.Index.termPostings(terms[0]) {
+		ao := &wm.Table.Ayat[wm.Table.Find(p.Offset)]
+		last := p.Pos + len(terms) - 1
+		if last >= len(ao.Words) || !ao.matchTerms(text, p.Pos, terms) {
+			continue
+		}
+
+		match := wm.Table.NewSearchMatch(p.Offset, time.Since(start))
+		match.Length = ao.wordEnd(last) - p.Offset
+		matches = append(matches, *match)
+		if max > 0 && len(matches) >= max {
+			break
+		}
+	}
+
+	return matches
+}
+
+func (wi *WordIndex) termPostings(term string) []Posting {
+	if strings.HasSuffix(term, wordPrefixMark) {
+		return wi.Prefix(strings.TrimSuffix(term, wordPrefixMark))
+	}
+	return wi.Lookup(term)
+}
+
+// matchTerms Check the words of the aya from pos on against the terms
+func (ao *AyaOffset) matchTerms(quran string, pos int, terms []string) bool {
+	for k, term := range terms {
+		word := normalizeWord(ao.word(quran, pos+k))
+		if strings.HasSuffix(term, wordPrefixMark) {
+			if !strings.HasPrefix(word, normalizeWord(strings.TrimSuffix(term, wordPrefixMark))) {
+				return false
+			}
+		} else if word != normalizeWord(term) {
+			return false
+		}
+	}
+	return true
+}
+
+// word Return the text of the word at pos
+func (ao *AyaOffset) word(quran string, pos int) string {
+	return quran[ao.Words[pos]:ao.wordEnd(pos)]
+}
+
+// wordEnd Return the end offset of the word at pos
+func (ao *AyaOffset) wordEnd(pos int) int {
+	if pos+1 < len(ao.Words) {
+		return ao.Words[pos+1] - 1
+	}
+	return ao.End
+}
+
+// isWordSeparator Check for the bytes around the space delimited words
+func isWordSeparator(b byte) bool {
+	return b == ' ' || b == '\n' || b == '\r' || b == '|'
+}
+
+// startsWord Check whether a word of the text starts at i
+func startsWord(text string, i int) bool {
+	return i == 0 || isWordSeparator(text[i-1])
+}
+
+// endsWord Check whether a word of the text ends at i, the marks written on
+// its last letter skipped
+func endsWord(text string, i int) bool {
+	for i < len(text) {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if r == 'ٱ' || !strings.ContainsRune(quranicMarks, r) {
+			break
+		}
+		i += size
+	}
+	return i == len(text) || isWordSeparator(text[i])
+}
+
+// normalizeWord Drop the diacritics and tatweel so words index alike
+func normalizeWord(w string) string {
+	return strings.Map(func(r rune) rune {
+		if r == 'ٱ' {
+			return 'ا'
+		}
+		if strings.ContainsRune(quranicMarks, r) {
+			return -1
+		}
+		return r
+	}, w)
+}
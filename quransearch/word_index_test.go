@@ -0,0 +1,98 @@
+package quransearch
+
+import (
+	"strings"
+	"testing"
+)
+
+// wholeWords Return the offsets of the whole word or phrase w found by
+// strings.Index, the truth the index is checked against
+func wholeWords(text, w string, prefix bool) []int {
+	var offsets []int
+	for i := 0; ; i++ {
+		found := strings.Index(text[i:], w)
+		if found == -1 {
+			return offsets
+		}
+		i += found
+		before := i == 0 || text[i-1] == ' ' || text[i-1] == '|'
+		end := i + len(w)
+		after := prefix || end == len(text) || text[end] == ' ' || text[end] == '\n'
+		if before && after {
+			offsets = append(offsets, i)
+		}
+	}
+}
+
+func postingOffsets(postings []Posting) []int {
+	offsets := make([]int, len(postings))
+	for i, p := range postings {
+		offsets[i] = p.Offset
+	}
+	return offsets
+}
+
+func sameOffsets(t *testing.T, what string, got, want []int) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: got %d offsets, want %d", what, len(got), len(want))
+		return
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("%s: offset %d is %d, want %d", what, i, got[i], want[i])
+			return
+		}
+	}
+}
+
+func TestWordIndexLookup(t *testing.T) {
+	qs := newTestSearch(t)
+	wi := NewWordIndex(qs.Quran, qs.Ayat)
+
+	for _, w := range []string{"الله", "موسى", "الصلاة", "من", "يوم", "قال"} {
+		want := wholeWords(qs.Quran, w, false)
+		if len(want) == 0 {
+			t.Fatalf("%s is not in the text", w)
+		}
+		sameOffsets(t, "Lookup "+w, postingOffsets(wi.Lookup(w)), want)
+	}
+	if postings := wi.Lookup("كتابكتاب"); len(postings) != 0 {
+		t.Errorf("Lookup of a missing word gave %d postings", len(postings))
+	}
+}
+
+func TestWordIndexPrefix(t *testing.T) {
+	qs := newTestSearch(t)
+	wi := NewWordIndex(qs.Quran, qs.Ayat)
+
+	for _, prefix := range []string{"الكت", "يؤمن", "سبح"} {
+		sameOffsets(t, "Prefix "+prefix, postingOffsets(wi.Prefix(prefix)), wholeWords(qs.Quran, prefix, true))
+	}
+}
+
+func TestWordIndexMethodSearch(t *testing.T) {
+	qs := newTestSearch(t)
+	wm := &WordIndexMethod{Table: qs.Ayat}
+
+	for _, phrase := range []string{"بسم الله الرحمن الرحيم", "يا أيها الذين آمنوا", "موسى"} {
+		matches := wm.Search(qs.Quran, phrase, -1)
+		offsets := make([]int, len(matches))
+		for i, m := range matches {
+			offsets[i] = m.Index
+			if m.Length != len(phrase) {
+				t.Errorf("%s: match length %d, want %d", phrase, m.Length, len(phrase))
+			}
+		}
+		sameOffsets(t, "Search "+phrase, offsets, wholeWords(qs.Quran, phrase, false))
+	}
+
+	if matches := wm.Search(qs.Quran, "يا أيها الذين آمنوا", 3); len(matches) != 3 {
+		t.Errorf("max 3 gave %d matches", len(matches))
+	}
+
+	// 2:1 ends with الم and 2:2 starts with ذلك
+	if matches := wm.Search(qs.Quran, "الم ذلك", -1); len(matches) != 0 {
+		t.Errorf("a phrase across ayat matched %d times", len(matches))
+	}
+}